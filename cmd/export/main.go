@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/services"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func main() {
+	format := flag.String("format", constants.FormatCSV, "export format, one of csv, json, ndjson")
+	out := flag.String("out", ".", "directory the files are written to")
+	flag.Parse()
+
+	helper := helpers.New()
+	repo, err := stores.NewRepository(context.Background(), helper)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	store := stores.New(repo, helper.Redis, helper.Logger, helper.Metrics.CacheHits, helper.Metrics.CacheMisses)
+	svc := services.NewExport(&store, &store)
+
+	// JSON holds both collections in a single document, the other formats need a file per collection.
+	entities := []string{constants.EntityCampaigns, constants.EntityRules}
+	if *format == constants.FormatJSON {
+		entities = []string{""}
+	}
+
+	for _, entity := range entities {
+		name := entity
+		if name == "" {
+			name = "export"
+		}
+
+		path := filepath.Join(*out, name+"."+*format)
+		if err := export(svc, path, *format, entity); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		fmt.Println("exported", path)
+	}
+}
+
+func export(svc services.ExportService, path, format, entity string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.Export(context.Background(), file, format, entity); err != nil {
+		file.Close()
+		os.Remove(path)
+
+		return err
+	}
+
+	return file.Close()
+}
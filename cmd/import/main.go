@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func main() {
+	campaignsPath := flag.String("campaigns", "", "path of the campaigns CSV file")
+	rulesPath := flag.String("rules", "", "path of the rules CSV file")
+	dryRun := flag.Bool("dry-run", false, "print the changes against the current data without applying them")
+	flag.Parse()
+
+	if *campaignsPath == "" && *rulesPath == "" {
+		fmt.Fprintln(os.Stderr, "at least one of -campaigns or -rules is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	campaignsCSV, err := openFile(*campaignsPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	rulesCSV, err := openFile(*rulesPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	helper := helpers.New()
+	repo, err := stores.NewRepository(context.Background(), helper)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	store := stores.New(repo, helper.Redis, helper.Logger, helper.Metrics.CacheHits, helper.Metrics.CacheMisses)
+	svc := services.NewImport(&store, &store, &store)
+
+	report, err := svc.Import(context.Background(), campaignsCSV, rulesCSV, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	printReport(report)
+
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// openFile returns nil for an empty path, the file is left for the process exit to close.
+func openFile(path string) (io.Reader, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	return os.Open(path)
+}
+
+func printReport(report *models.ImportReport) {
+	for _, importErr := range report.Errors {
+		fmt.Printf("error %s:%d %s: %s\n", importErr.File, importErr.Row, importErr.CampaignID, importErr.Reason)
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Printf("%d invalid rows, nothing was imported\n", len(report.Errors))
+		return
+	}
+
+	symbols := map[string]string{"create": "+", "update": "~", "unchanged": "="}
+
+	for _, change := range report.Changes {
+		fmt.Printf("%s %s %s\n", symbols[change.Action], change.Entity, change.CampaignID)
+
+		if len(change.Diff) > 0 {
+			fmt.Println("    " + strings.Join(change.Diff, "\n    "))
+		}
+	}
+
+	if report.DryRun {
+		fmt.Println("dry run, nothing was imported")
+	}
+}
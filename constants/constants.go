@@ -4,4 +4,110 @@ const (
 	App     = "app"
 	Country = "country"
 	Os      = "os"
+
+	// The optional dimensions, a request without them only matches campaigns having no rule on them.
+	AppVersion = "app_version"
+	OsVersion  = "os_version"
+	Device     = "device"
+	Lang       = "lang"
+
+	// Daypart restricts a campaign to time windows of the user's local time, its rules take windows instead of values.
+	Daypart = "daypart"
+
+	// Expression is the boolean expression a campaign may have on top of its rules, it is explained like a dimension.
+	Expression = "expression"
+)
+
+// The sources of the country of a delivery request.
+const (
+	CountrySourceQuery = "query"
+	CountrySourceGeoIP = "geoip"
+	CountrySourceNone  = "none"
 )
+
+const (
+	BackendMongo    = "mongo"
+	BackendPostgres = "postgres"
+	BackendFile     = "file"
+)
+
+const (
+	DependencyUp       = "up"
+	DependencyDown     = "down"
+	DependencyDisabled = "disabled"
+
+	DependencyRedis = "redis"
+)
+
+const (
+	StatusActive   = "ACTIVE"
+	StatusInactive = "INACTIVE"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatZip    = "zip"
+
+	EntityCampaigns = "campaigns"
+	EntityRules     = "rules"
+)
+
+const (
+	EventImpression = "impression"
+	EventClick      = "click"
+	EventDismiss    = "dismiss"
+)
+
+// EventTypes are the events reported for the delivered campaigns.
+var EventTypes = []string{EventClick, EventDismiss, EventImpression}
+
+// The pacing states of a campaign with an impression goal. Untracked ones are delivered without counting, as there
+// is no Redis.
+const (
+	PacingOnPace           = "on_pace"
+	PacingAhead            = "ahead"
+	PacingDailyGoalReached = "daily_goal_reached"
+	PacingGoalReached      = "goal_reached"
+	PacingUntracked        = "untracked"
+)
+
+const (
+	EventSinkMongo = "mongo"
+	EventSinkFile  = "file"
+)
+
+const (
+	VerdictNoRule      = "no_rule"
+	VerdictIncluded    = "included"
+	VerdictNotExcluded = "not_excluded"
+	VerdictExcluded    = "excluded"
+	VerdictNotIncluded = "not_included"
+	VerdictUnspecified = "unspecified"
+
+	VerdictInWindow      = "in_window"
+	VerdictOutsideWindow = "outside_window"
+
+	VerdictSatisfied    = "satisfied"
+	VerdictNotSatisfied = "not_satisfied"
+)
+
+// Dimensions lists every dimension matched by value.
+var Dimensions = []string{App, Country, Os, AppVersion, OsVersion, Device, Lang}
+
+// RequiredDimensions lists the dimensions every delivery request has.
+var RequiredDimensions = []string{App, Country, Os}
+
+// RuleDimensions lists every dimension a targeting rule can be defined on.
+var RuleDimensions = []string{App, Country, Os, AppVersion, OsVersion, Device, Lang, Daypart}
+
+// VersionDimensions take version ranges like >=5.2.0 besides versions. They are matched by the service rather than by
+// the index or the store queries.
+var VersionDimensions = []string{AppVersion, OsVersion}
+
+// Devices are the values of the device dimension.
+var Devices = []string{"phone", "tablet", "tv"}
+
+// Weekdays are the day names of the time windows, in the order of time.Weekday.
+var Weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+type CampaignHandler struct {
+	services.Campaign
+	ErrorMetrics *prometheus.CounterVec
+}
+
+func NewCampaignHandler(svc services.Campaign, errorMetrics *prometheus.CounterVec) CampaignHandler {
+	return CampaignHandler{Campaign: svc, ErrorMetrics: errorMetrics}
+}
+
+func (h *CampaignHandler) Create(ctx *gin.Context) {
+	var campaign models.Campaign
+	if err := ctx.ShouldBindJSON(&campaign); err != nil {
+		writeError(ctx, h.ErrorMetrics, invalidBody(err))
+		return
+	}
+
+	created, err := h.Campaign.Create(ctx, &campaign)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.FormResponse(created))
+}
+
+func (h *CampaignHandler) GetByID(ctx *gin.Context) {
+	campaign, err := h.Campaign.GetByID(ctx, ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(campaign))
+}
+
+func (h *CampaignHandler) GetAll(ctx *gin.Context) {
+	campaigns, err := h.Campaign.GetAll(ctx, ctx.Query("status"))
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(campaigns))
+}
+
+func (h *CampaignHandler) Update(ctx *gin.Context) {
+	var campaign models.Campaign
+	if err := ctx.ShouldBindJSON(&campaign); err != nil {
+		writeError(ctx, h.ErrorMetrics, invalidBody(err))
+		return
+	}
+
+	updated, err := h.Campaign.Update(ctx, ctx.Param("id"), &campaign)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(updated))
+}
+
+func (h *CampaignHandler) Patch(ctx *gin.Context) {
+	var patch models.CampaignPatch
+	if err := ctx.ShouldBindJSON(&patch); err != nil {
+		writeError(ctx, h.ErrorMetrics, invalidBody(err))
+		return
+	}
+
+	updated, err := h.Campaign.Patch(ctx, ctx.Param("id"), &patch)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(updated))
+}
+
+func (h *CampaignHandler) Delete(ctx *gin.Context) {
+	if err := h.Campaign.Delete(ctx, ctx.Param("id")); err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func writeError(ctx *gin.Context, errorMetrics *prometheus.CounterVec, err error) {
+	statusCode, body := helpers.ParseError(err)
+	errorMetrics.WithLabelValues(ctx.Request.Method, ctx.FullPath(), strconv.Itoa(statusCode)).Inc()
+	ctx.JSON(statusCode, body)
+}
+
+func invalidBody(err error) error {
+	return &helpers.Error{StatusCode: http.StatusBadRequest, Code: "Invalid Body", Reason: err.Error()}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+func newTestErrorMetrics() *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_errors"}, []string{"method", "endpoint", "statusCode"})
+}
+
+func TestCampaignHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCampaign := services.NewMockCampaign(ctrl)
+	campaign := &models.Campaign{CampaignID: "spotify", Name: "Spotify Campaign",
+		Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "ACTIVE"}
+	campaignJSON := `{"campaign_id":"spotify","name":"Spotify Campaign","image":"https://example.com/images/spotify.png",` +
+		`"cta":"Listen Now","status":"ACTIVE"}`
+	inactive, endAt := "INACTIVE", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		mockCalls      []interface{}
+		expectedStatus int
+	}{
+		{
+			name:   "create campaign",
+			method: http.MethodPost,
+			path:   "/v1/campaigns",
+			body:   campaignJSON,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().Create(gomock.Any(), campaign).Return(campaign, nil),
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create campaign with malformed body",
+			method:         http.MethodPost,
+			path:           "/v1/campaigns",
+			body:           `{"campaign_id":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "create campaign conflict",
+			method: http.MethodPost,
+			path:   "/v1/campaigns",
+			body:   campaignJSON,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().Create(gomock.Any(), campaign).
+					Return(nil, &helpers.Error{StatusCode: http.StatusConflict}),
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "list campaigns",
+			method: http.MethodGet,
+			path:   "/v1/campaigns?status=ACTIVE",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetAll(gomock.Any(), "ACTIVE").Return([]models.Campaign{*campaign}, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "get campaign not found",
+			method: http.MethodGet,
+			path:   "/v1/campaigns/unknown",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetByID(gomock.Any(), "unknown").
+					Return(nil, &helpers.Error{StatusCode: http.StatusNotFound}),
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "update campaign",
+			method: http.MethodPut,
+			path:   "/v1/campaigns/spotify",
+			body:   campaignJSON,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().Update(gomock.Any(), "spotify", campaign).Return(campaign, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "patch campaign",
+			method: http.MethodPatch,
+			path:   "/v1/campaigns/spotify",
+			body:   `{"status":"INACTIVE"}`,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().Patch(gomock.Any(), "spotify", &models.CampaignPatch{Status: &inactive}).
+					Return(campaign, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "patch campaign schedule, null removing a bound",
+			method: http.MethodPatch,
+			path:   "/v1/campaigns/spotify",
+			body:   `{"start_at":null,"end_at":"2026-04-01T00:00:00Z"}`,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().Patch(gomock.Any(), "spotify", &models.CampaignPatch{
+					StartAt: models.OptionalTime{Set: true}, EndAt: models.OptionalTime{Set: true, Time: &endAt}}).
+					Return(campaign, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "delete campaign",
+			method: http.MethodDelete,
+			path:   "/v1/campaigns/spotify",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().Delete(gomock.Any(), "spotify").Return(nil),
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	handler := NewCampaignHandler(mockCampaign, newTestErrorMetrics())
+
+	router := gin.New()
+	router.POST("/v1/campaigns", handler.Create)
+	router.GET("/v1/campaigns", handler.GetAll)
+	router.GET("/v1/campaigns/:id", handler.GetByID)
+	router.PUT("/v1/campaigns/:id", handler.Update)
+	router.PATCH("/v1/campaigns/:id", handler.Patch)
+	router.DELETE("/v1/campaigns/:id", handler.Delete)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
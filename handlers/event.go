@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+type EventHandler struct {
+	services.Event
+	ErrorMetrics *prometheus.CounterVec
+}
+
+func NewEventHandler(svc services.Event, errorMetrics *prometheus.CounterVec) EventHandler {
+	return EventHandler{Event: svc, ErrorMetrics: errorMetrics}
+}
+
+// eventsBody is either a single event, or an object with the events batched by the client.
+type eventsBody struct {
+	Events []models.Event
+}
+
+func (b *eventsBody) UnmarshalJSON(data []byte) error {
+	var batch models.EventBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return err
+	}
+
+	if batch.Events != nil {
+		b.Events = batch.Events
+		return nil
+	}
+
+	var event models.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return err
+	}
+
+	b.Events = []models.Event{event}
+
+	return nil
+}
+
+// Create accepts the events once they are buffered, they are written to the sink shortly after.
+func (h *EventHandler) Create(ctx *gin.Context) {
+	var body eventsBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		writeError(ctx, h.ErrorMetrics, invalidBody(err))
+		return
+	}
+
+	receipt, err := h.Event.Record(ctx, body.Events)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, helpers.FormResponse(receipt))
+}
+
+// Beacon records the event of a signed beacon URL, the token coming from a delivery response.
+func (h *EventHandler) Beacon(ctx *gin.Context) {
+	if err := h.Event.Track(ctx, ctx.Query("token")); err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+func TestEventHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEvent := services.NewMockEvent(ctrl)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	click := models.Event{Type: "click", CampaignID: "spotify", Timestamp: at,
+		Dimensions: models.EventDimensions{App: "com.spotify", Country: "in", OS: "android"}}
+
+	tests := []struct {
+		name           string
+		body           string
+		mockCalls      []interface{}
+		expectedStatus int
+	}{
+		{
+			name: "single event",
+			body: `{"type":"click","campaign_id":"spotify","timestamp":"2026-03-01T12:00:00Z",` +
+				`"dimensions":{"app":"com.spotify","country":"in","os":"android"}}`,
+			mockCalls: []interface{}{
+				mockEvent.EXPECT().Record(gomock.Any(), []models.Event{click}).Return(&models.EventReceipt{Accepted: 1}, nil),
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "batch of events",
+			body: `{"events":[{"type":"click","campaign_id":"spotify","timestamp":"2026-03-01T12:00:00Z",` +
+				`"dimensions":{"app":"com.spotify","country":"in","os":"android"}},` +
+				`{"type":"dismiss","campaign_id":"zepto","timestamp":"2026-03-01T12:00:00Z"}]}`,
+			mockCalls: []interface{}{
+				mockEvent.EXPECT().Record(gomock.Any(), []models.Event{click,
+					{Type: "dismiss", CampaignID: "zepto", Timestamp: at}}).Return(&models.EventReceipt{Accepted: 2}, nil),
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "malformed body",
+			body:           `{"events":{"type":"click"}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "buffer is full",
+			body: `{"type":"click","campaign_id":"spotify","timestamp":"2026-03-01T12:00:00Z"}`,
+			mockCalls: []interface{}{
+				mockEvent.EXPECT().Record(gomock.Any(), gomock.Any()).
+					Return(nil, &helpers.Error{StatusCode: http.StatusServiceUnavailable}),
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	handler := NewEventHandler(mockEvent, newTestErrorMetrics())
+
+	router := gin.New()
+	router.POST("/v1/events", handler.Create)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/events", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestEventHandler_Beacon(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEvent := services.NewMockEvent(ctrl)
+	handler := NewEventHandler(mockEvent, newTestErrorMetrics())
+
+	router := gin.New()
+	router.GET(helpers.BeaconPath, handler.Beacon)
+
+	gomock.InOrder(
+		mockEvent.EXPECT().Track(gomock.Any(), "k1.claims.signature").Return(nil),
+		mockEvent.EXPECT().Track(gomock.Any(), "k1.claims.signature").
+			Return(&helpers.Error{StatusCode: http.StatusConflict, Reason: "Beacon token was already used"}),
+	)
+
+	for _, expectedStatus := range []int{http.StatusNoContent, http.StatusConflict} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, helpers.BeaconPath+"?token=k1.claims.signature", nil))
+
+		assert.Equal(t, expectedStatus, w.Code)
+	}
+}
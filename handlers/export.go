@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+var contentTypes = map[string]string{
+	constants.FormatCSV:    "text/csv",
+	constants.FormatJSON:   "application/json",
+	constants.FormatNDJSON: "application/x-ndjson",
+	constants.FormatZip:    "application/zip",
+}
+
+type ExportHandler struct {
+	services.Export
+	ErrorMetrics *prometheus.CounterVec
+}
+
+func NewExportHandler(svc services.Export, errorMetrics *prometheus.CounterVec) ExportHandler {
+	return ExportHandler{Export: svc, ErrorMetrics: errorMetrics}
+}
+
+// Get responds with the export as an attachment, it is buffered so that a failure is still reported as an error. The
+// format and the entity are normalized first, the content type and the file name following the exported format. CSV
+// and NDJSON without an entity are a zip archive of both entities.
+func (h *ExportHandler) Get(ctx *gin.Context) {
+	format := strings.ToLower(strings.TrimSpace(ctx.DefaultQuery("format", constants.FormatJSON)))
+	entity := strings.ToLower(strings.TrimSpace(ctx.Query("entity")))
+
+	var buf bytes.Buffer
+	if err := h.Export.Export(ctx, &buf, format, entity); err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	fileName, extension := entity, format
+	if entity == "" {
+		fileName = "export"
+
+		if format != constants.FormatJSON {
+			extension = constants.FormatZip
+		}
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename="+fileName+"."+extension)
+	ctx.Data(http.StatusOK, contentTypes[extension], buf.Bytes())
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+func TestExportHandler_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExport := services.NewMockExport(ctrl)
+	writeExport := func(_ context.Context, w io.Writer, _, _ string) error {
+		_, err := w.Write([]byte("exported"))
+		return err
+	}
+
+	tests := []struct {
+		name                string
+		path                string
+		mockCalls           []interface{}
+		expectedContentType string
+		expectedDisposition string
+	}{
+		{
+			name: "everything as json by default",
+			path: "/v1/export",
+			mockCalls: []interface{}{
+				mockExport.EXPECT().Export(gomock.Any(), gomock.Any(), "json", "").DoAndReturn(writeExport),
+			},
+			expectedContentType: "application/json",
+			expectedDisposition: "attachment; filename=export.json",
+		},
+		{
+			name: "format and entity are normalized",
+			path: "/v1/export?format=%20CSV%20&entity=Rules",
+			mockCalls: []interface{}{
+				mockExport.EXPECT().Export(gomock.Any(), gomock.Any(), "csv", "rules").DoAndReturn(writeExport),
+			},
+			expectedContentType: "text/csv",
+			expectedDisposition: "attachment; filename=rules.csv",
+		},
+		{
+			name: "both entities as a zip archive without entity",
+			path: "/v1/export?format=csv",
+			mockCalls: []interface{}{
+				mockExport.EXPECT().Export(gomock.Any(), gomock.Any(), "csv", "").DoAndReturn(writeExport),
+			},
+			expectedContentType: "application/zip",
+			expectedDisposition: "attachment; filename=export.zip",
+		},
+	}
+
+	handler := NewExportHandler(mockExport, newTestErrorMetrics())
+
+	router := gin.New()
+	router.GET("/v1/export", handler.Get)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedDisposition, w.Header().Get("Content-Disposition"))
+			assert.Equal(t, "exported", w.Body.String())
+		})
+	}
+}
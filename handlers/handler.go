@@ -24,43 +24,69 @@ func New(svc services.Delivery, errorMetrics *prometheus.CounterVec) Handler {
 }
 
 func (h *Handler) Get(ctx *gin.Context) {
-	appID := ctx.Query(constants.App)
-	if strings.TrimSpace(appID) == "" {
-		h.ErrorMetrics.WithLabelValues(ctx.Request.Method, ctx.Request.URL.Path, strconv.Itoa(http.StatusBadRequest)).Inc()
-		ctx.JSON(helpers.ParseError(&helpers.Error{StatusCode: http.StatusBadRequest,
-			Code: "Invalid Param", Reason: "Parameter app is required"}))
+	d, err := dimensionsFromQuery(ctx)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
 		return
 	}
 
-	country := ctx.Query(constants.Country)
-	if strings.TrimSpace(country) == "" {
-		h.ErrorMetrics.WithLabelValues(ctx.Request.Method, ctx.Request.URL.Path, strconv.Itoa(http.StatusBadRequest)).Inc()
-		ctx.JSON(helpers.ParseError(&helpers.Error{StatusCode: http.StatusBadRequest,
-			Code: "Invalid Param", Reason: "Parameter country is required"}))
+	// Without a limit, every campaign is delivered.
+	var limit int
+	if value, ok := ctx.GetQuery("limit"); ok {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			writeError(ctx, h.ErrorMetrics, &helpers.Error{StatusCode: http.StatusBadRequest, Code: "Invalid Param",
+				Reason: "Parameter limit must be a positive integer"})
+			return
+		}
+	}
+
+	campaigns, err := h.Delivery.Get(ctx, d, limit)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
 		return
 	}
 
-	os := ctx.Query(constants.Os)
-	if strings.TrimSpace(os) == "" {
-		h.ErrorMetrics.WithLabelValues(ctx.Request.Method, ctx.Request.URL.Path, strconv.Itoa(http.StatusBadRequest)).Inc()
-		ctx.JSON(helpers.ParseError(&helpers.Error{StatusCode: http.StatusBadRequest,
-			Code: "Invalid Param", Reason: "Parameter os is required"}))
+	if campaigns == nil || len(*campaigns) == 0 {
+		ctx.JSON(http.StatusNoContent, nil)
 		return
 	}
 
-	d := &models.Dimension{APPID: appID, Country: country, OS: os}
-	campaigns, err := h.Delivery.Get(ctx, d)
+	ctx.JSON(http.StatusOK, helpers.FormResponse(campaigns))
+}
+
+// Explain takes the same params as Get, along with an optional campaign_id, and tells for every campaign whether
+// it is delivered and the verdict of each dimension.
+func (h *Handler) Explain(ctx *gin.Context) {
+	d, err := dimensionsFromQuery(ctx)
 	if err != nil {
-		statusCode, err := helpers.ParseError(err)
-		h.ErrorMetrics.WithLabelValues(ctx.Request.Method, ctx.Request.URL.Path, strconv.Itoa(statusCode)).Inc()
-		ctx.JSON(statusCode, err)
+		writeError(ctx, h.ErrorMetrics, err)
 		return
 	}
 
-	if campaigns == nil || len(*campaigns) == 0 {
-		ctx.JSON(http.StatusNoContent, nil)
+	explanations, err := h.Delivery.Explain(ctx, d, ctx.Query("campaign_id"))
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, helpers.FormResponse(campaigns))
+	ctx.JSON(http.StatusOK, helpers.FormResponse(explanations))
+}
+
+func dimensionsFromQuery(ctx *gin.Context) (*models.Dimension, error) {
+	appID := ctx.Query(constants.App)
+	if strings.TrimSpace(appID) == "" {
+		return nil, &helpers.Error{StatusCode: http.StatusBadRequest, Code: "Invalid Param", Reason: "Parameter app is required"}
+	}
+
+	os := ctx.Query(constants.Os)
+	if strings.TrimSpace(os) == "" {
+		return nil, &helpers.Error{StatusCode: http.StatusBadRequest, Code: "Invalid Param", Reason: "Parameter os is required"}
+	}
+
+	// Without the country param, the country is resolved from the client IP, which is in the X-Forwarded-For header of
+	// the trusted proxies.
+	return &models.Dimension{APPID: appID, Country: ctx.Query(constants.Country), OS: os,
+		AppVersion: ctx.Query(constants.AppVersion), OSVersion: ctx.Query(constants.OsVersion),
+		Device: ctx.Query(constants.Device), Lang: ctx.Query(constants.Lang), TZ: ctx.Query("tz"),
+		UserID: strings.TrimSpace(ctx.Query("user_id")), ClientIP: ctx.ClientIP()}, nil
 }
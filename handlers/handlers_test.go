@@ -37,7 +37,7 @@ func TestHandler_Get(t *testing.T) {
 				constants.Os:      "Android",
 			},
 			mockCalls: []interface{}{
-				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android"}).
+				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android", ClientIP: "192.0.2.1"}, 0).
 					Return(&[]models.Response{{CampaignID: "spotify"}, {CampaignID: "zepto"}}, nil),
 			},
 			expectedStatus: http.StatusOK,
@@ -50,11 +50,29 @@ func TestHandler_Get(t *testing.T) {
 				constants.Os:      "Android",
 			},
 			mockCalls: []interface{}{
-				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android"}).
+				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android", ClientIP: "192.0.2.1"}, 0).
 					Return(nil, nil),
 			},
 			expectedStatus: http.StatusNoContent,
 		},
+		{
+			name: "optional parameters",
+			queryParams: map[string]string{
+				constants.App:        "com.app.test",
+				constants.Country:    "US",
+				constants.Os:         "Android",
+				constants.AppVersion: "5.2.0",
+				constants.OsVersion:  "12",
+				constants.Device:     "tablet",
+				constants.Lang:       "en",
+				"tz":                 "Asia/Kolkata",
+			},
+			mockCalls: []interface{}{
+				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android",
+					AppVersion: "5.2.0", OSVersion: "12", Device: "tablet", Lang: "en", TZ: "Asia/Kolkata", ClientIP: "192.0.2.1"}, 0).Return(nil, nil),
+			},
+			expectedStatus: http.StatusNoContent,
+		},
 		{
 			name: "missing app parameter",
 			queryParams: map[string]string{
@@ -64,11 +82,15 @@ func TestHandler_Get(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "missing country parameter",
+			name: "missing country parameter is left to the service",
 			queryParams: map[string]string{
 				constants.App: "com.app.test",
 				constants.Os:  "Android",
 			},
+			mockCalls: []interface{}{
+				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", OS: "Android", ClientIP: "192.0.2.1"}, 0).
+					Return(nil, &helpers.Error{StatusCode: http.StatusBadRequest}),
+			},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
@@ -79,6 +101,44 @@ func TestHandler_Get(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "limit",
+			queryParams: map[string]string{
+				constants.App:     "com.app.test",
+				constants.Country: "US",
+				constants.Os:      "Android",
+				"limit":           "1",
+			},
+			mockCalls: []interface{}{
+				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android", ClientIP: "192.0.2.1"}, 1).
+					Return(&[]models.Response{{CampaignID: "spotify"}}, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "user id",
+			queryParams: map[string]string{
+				constants.App:     "com.app.test",
+				constants.Country: "US",
+				constants.Os:      "Android",
+				"user_id":         " Device-1 ",
+			},
+			mockCalls: []interface{}{
+				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android",
+					UserID: "Device-1", ClientIP: "192.0.2.1"}, 0).Return(&[]models.Response{{CampaignID: "spotify"}}, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "limit isn't a positive integer",
+			queryParams: map[string]string{
+				constants.App:     "com.app.test",
+				constants.Country: "US",
+				constants.Os:      "Android",
+				"limit":           "0",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "service returns error",
 			queryParams: map[string]string{
@@ -87,7 +147,7 @@ func TestHandler_Get(t *testing.T) {
 				constants.Os:      "Android",
 			},
 			mockCalls: []interface{}{
-				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android"}).
+				mockDelivery.EXPECT().Get(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android", ClientIP: "192.0.2.1"}, 0).
 					Return(nil, &helpers.Error{StatusCode: http.StatusInternalServerError}),
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -96,7 +156,7 @@ func TestHandler_Get(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := New(mockDelivery)
+			handler := New(mockDelivery, newTestErrorMetrics())
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -114,3 +174,56 @@ func TestHandler_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Explain(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDelivery := services.NewMockDelivery(ctrl)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockCalls      []interface{}
+		expectedStatus int
+	}{
+		{
+			name:  "successful response",
+			query: "app=com.app.test&country=US&os=Android&campaign_id=spotify",
+			mockCalls: []interface{}{
+				mockDelivery.EXPECT().Explain(gomock.Any(), &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android", ClientIP: "192.0.2.1"}, "spotify").
+					Return([]models.Explanation{{CampaignID: "spotify", Delivered: true}}, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing os parameter",
+			query:          "app=com.app.test&country=US",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "service returns error",
+			query: "app=com.app.test&country=US&os=Android",
+			mockCalls: []interface{}{
+				mockDelivery.EXPECT().Explain(gomock.Any(), gomock.Any(), "").
+					Return(nil, &helpers.Error{StatusCode: http.StatusInternalServerError}),
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := New(mockDelivery, newTestErrorMetrics())
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/dummy?"+tt.query, nil)
+
+			handler.Explain(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+type HealthHandler struct {
+	services.Health
+}
+
+func NewHealthHandler(svc services.Health) HealthHandler {
+	return HealthHandler{Health: svc}
+}
+
+// Live answers as long as the process serves requests, it doesn't check any dependency.
+func (h *HealthHandler) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, helpers.FormResponse(map[string]string{"status": "ok"}))
+}
+
+// Ready answers 503 along with the reasons when the service shouldn't receive traffic.
+func (h *HealthHandler) Ready(ctx *gin.Context) {
+	readiness := h.Health.Ready(ctx)
+
+	statusCode := http.StatusOK
+	if !readiness.Ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(statusCode, helpers.FormResponse(readiness))
+}
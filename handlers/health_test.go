@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+func TestHealthHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHealth := services.NewMockHealth(ctrl)
+
+	tests := []struct {
+		name           string
+		path           string
+		mockCalls      []interface{}
+		expectedStatus int
+	}{
+		{
+			name:           "alive",
+			path:           "/healthz",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "ready",
+			path: "/readyz",
+			mockCalls: []interface{}{
+				mockHealth.EXPECT().Ready(gomock.Any()).Return(&models.Readiness{Ready: true}),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "not ready",
+			path: "/readyz",
+			mockCalls: []interface{}{
+				mockHealth.EXPECT().Ready(gomock.Any()).Return(&models.Readiness{Reasons: []string{"shutting down"}}),
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	handler := NewHealthHandler(mockHealth)
+
+	router := gin.New()
+	router.GET("/healthz", handler.Live)
+	router.GET("/readyz", handler.Ready)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
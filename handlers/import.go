@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+type ImportHandler struct {
+	services.Import
+	ErrorMetrics *prometheus.CounterVec
+}
+
+func NewImportHandler(svc services.Import, errorMetrics *prometheus.CounterVec) ImportHandler {
+	return ImportHandler{Import: svc, ErrorMetrics: errorMetrics}
+}
+
+// Create expects a multipart form with the campaigns and rules CSV files, at least one of them must be present.
+func (h *ImportHandler) Create(ctx *gin.Context) {
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, &helpers.Error{StatusCode: http.StatusBadRequest, Code: "Invalid Param",
+			Reason: "Parameter dry_run must be a boolean"})
+		return
+	}
+
+	campaignsCSV, err := openFormFile(ctx, "campaigns")
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, invalidBody(err))
+		return
+	}
+
+	if campaignsCSV != nil {
+		defer campaignsCSV.Close()
+	}
+
+	rulesCSV, err := openFormFile(ctx, "rules")
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, invalidBody(err))
+		return
+	}
+
+	if rulesCSV != nil {
+		defer rulesCSV.Close()
+	}
+
+	report, err := h.Import.Import(ctx, readerOrNil(campaignsCSV), readerOrNil(rulesCSV), dryRun)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		h.ErrorMetrics.WithLabelValues(ctx.Request.Method, ctx.FullPath(), strconv.Itoa(http.StatusUnprocessableEntity)).Inc()
+		ctx.JSON(http.StatusUnprocessableEntity, helpers.FormResponse(report))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(report))
+}
+
+// openFormFile returns nil when the file is not part of the form.
+func openFormFile(ctx *gin.Context, name string) (multipart.File, error) {
+	header, err := ctx.FormFile(name)
+	if err == http.ErrMissingFile {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return header.Open()
+}
+
+// readerOrNil avoids handing a nil multipart.File to the service as a non-nil io.Reader.
+func readerOrNil(file multipart.File) io.Reader {
+	if file == nil {
+		return nil
+	}
+
+	return file
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+type PacingHandler struct {
+	services.Pacing
+	ErrorMetrics *prometheus.CounterVec
+}
+
+func NewPacingHandler(svc services.Pacing, errorMetrics *prometheus.CounterVec) PacingHandler {
+	return PacingHandler{Pacing: svc, ErrorMetrics: errorMetrics}
+}
+
+// Get responds with the pacing of the campaigns with an impression goal, or of the campaign_id one.
+func (h *PacingHandler) Get(ctx *gin.Context) {
+	pacing, err := h.Pacing.Get(ctx, ctx.Query("campaign_id"))
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(pacing))
+}
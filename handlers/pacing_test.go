@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+func TestPacingHandler_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPacing := services.NewMockPacing(ctrl)
+
+	tests := []struct {
+		name           string
+		path           string
+		mockCalls      []interface{}
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "every campaign",
+			path: "/v1/admin/pacing",
+			mockCalls: []interface{}{
+				mockPacing.EXPECT().Get(gomock.Any(), "").Return([]models.Pacing{{CampaignID: "spotify", TotalGoal: 1000,
+					DeliveredTotal: 1000, State: "goal_reached"}}, nil),
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"campaign_id":"spotify","total_goal":1000,"delivered_total":1000,` +
+				`"delivered_today":0,"probability":0,"state":"goal_reached"}]}`,
+		},
+		{
+			name: "campaign without goal",
+			path: "/v1/admin/pacing?campaign_id=zepto",
+			mockCalls: []interface{}{
+				mockPacing.EXPECT().Get(gomock.Any(), "zepto").Return(nil, &helpers.Error{Code: "Invalid Param",
+					StatusCode: http.StatusBadRequest, Reason: "Campaign zepto has no impression goal"}),
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	handler := NewPacingHandler(mockPacing, newTestErrorMetrics())
+
+	router := gin.New()
+	router.GET("/v1/admin/pacing", handler.Get)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
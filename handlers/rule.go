@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+type RuleHandler struct {
+	services.Rule
+	ErrorMetrics *prometheus.CounterVec
+}
+
+func NewRuleHandler(svc services.Rule, errorMetrics *prometheus.CounterVec) RuleHandler {
+	return RuleHandler{Rule: svc, ErrorMetrics: errorMetrics}
+}
+
+func (h *RuleHandler) Get(ctx *gin.Context) {
+	rule, err := h.Rule.Get(ctx, ctx.Param("id"))
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(rule))
+}
+
+// rulesBody is either the list of rules, or an object with the rules and the expression.
+type rulesBody struct {
+	Rules      []models.Rule `json:"rules"`
+	Expression string        `json:"expression"`
+}
+
+func (b *rulesBody) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(data, &b.Rules)
+	}
+
+	type object rulesBody
+
+	return json.Unmarshal(data, (*object)(b))
+}
+
+func (h *RuleHandler) Save(ctx *gin.Context) {
+	var body rulesBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		writeError(ctx, h.ErrorMetrics, invalidBody(err))
+		return
+	}
+
+	rule, err := h.Rule.Save(ctx, ctx.Param("id"), body.Rules, body.Expression)
+	if err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.FormResponse(rule))
+}
+
+func (h *RuleHandler) Delete(ctx *gin.Context) {
+	if err := h.Rule.Delete(ctx, ctx.Param("id")); err != nil {
+		writeError(ctx, h.ErrorMetrics, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/services"
+)
+
+func TestRuleHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRule := services.NewMockRule(ctrl)
+	rules := []models.Rule{{Dimension: "country", Include: []string{"us"}}}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		mockCalls      []interface{}
+		expectedStatus int
+	}{
+		{
+			name:   "get rules",
+			method: http.MethodGet,
+			mockCalls: []interface{}{
+				mockRule.EXPECT().Get(gomock.Any(), "spotify").
+					Return(&models.TargetingRule{CampaignID: "spotify", Rules: rules}, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "save rules",
+			method: http.MethodPut,
+			body:   `[{"dimension":"country","include":["us"]}]`,
+			mockCalls: []interface{}{
+				mockRule.EXPECT().Save(gomock.Any(), "spotify", rules, "").
+					Return(&models.TargetingRule{CampaignID: "spotify", Rules: rules}, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "save rules with an expression",
+			method: http.MethodPut,
+			body:   `{"rules":[{"dimension":"country","include":["us"]}],"expression":"os = ios or device = tablet"}`,
+			mockCalls: []interface{}{
+				mockRule.EXPECT().Save(gomock.Any(), "spotify", rules, "os = ios or device = tablet").
+					Return(&models.TargetingRule{CampaignID: "spotify", Rules: rules}, nil),
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "save rules with malformed body",
+			method:         http.MethodPut,
+			body:           `{"rules":{"dimension":"country"}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "save invalid rules",
+			method: http.MethodPut,
+			body:   `[{"dimension":"city","include":["paris"]}]`,
+			mockCalls: []interface{}{
+				mockRule.EXPECT().Save(gomock.Any(), "spotify", gomock.Any(), "").
+					Return(nil, &helpers.Error{StatusCode: http.StatusBadRequest}),
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "delete rules",
+			method: http.MethodDelete,
+			mockCalls: []interface{}{
+				mockRule.EXPECT().Delete(gomock.Any(), "spotify").Return(nil),
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	handler := NewRuleHandler(mockRule, newTestErrorMetrics())
+
+	router := gin.New()
+	router.GET("/v1/campaigns/:id/rules", handler.Get)
+	router.PUT("/v1/campaigns/:id/rules", handler.Save)
+	router.DELETE("/v1/campaigns/:id/rules", handler.Delete)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/v1/campaigns/spotify/rules", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// BeaconPath is the endpoint the beacon URLs point to.
+const BeaconPath = "/v1/events/beacon"
+
+// minBeaconSecret is the shortest secret accepted, shorter ones are too easy to guess from the tokens.
+const minBeaconSecret = 32
+
+// beaconClockSkew is how far in the future a token may have been issued by another instance.
+const beaconClockSkew = time.Minute
+
+var (
+	ErrBeaconInvalid = errors.New("beacon token is invalid")
+	ErrBeaconExpired = errors.New("beacon token has expired")
+)
+
+// BeaconSigner issues and verifies the tokens of the beacon URLs, kid.claims.signature with the claims as base64url
+// JSON. Tokens are signed with the first key and verified with any of them.
+type BeaconSigner struct {
+	keys    []models.BeaconKey
+	ttl     time.Duration
+	baseURL string
+}
+
+// NewBeaconSigner returns nil without keys, the responses then have no beacon URLs.
+func NewBeaconSigner(keys []models.BeaconKey, ttl time.Duration, baseURL string) *BeaconSigner {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return &BeaconSigner{keys: keys, ttl: ttl, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// URL returns the beacon of the event type for the campaign delivered with the dimensions.
+func (s *BeaconSigner) URL(eventType, campaignID string, dimensions *models.Dimension, now time.Time) (string, error) {
+	token, err := s.Sign(&models.BeaconClaims{Type: eventType, CampaignID: campaignID, Dimensions: models.EventDimensions{
+		App: dimensions.APPID, Country: dimensions.Country, OS: dimensions.OS, AppVersion: dimensions.AppVersion,
+		OSVersion: dimensions.OSVersion, Device: dimensions.Device, Lang: dimensions.Lang, UserID: dimensions.UserID},
+		IssuedAt: now.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	return s.baseURL + BeaconPath + "?token=" + url.QueryEscape(token), nil
+}
+
+// Sign sets a random nonce on the claims, every token being usable once.
+func (s *BeaconSigner) Sign(claims *models.BeaconClaims) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	claims.Nonce = base64.RawURLEncoding.EncodeToString(nonce)
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	key := s.keys[0]
+	signed := key.ID + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature(key.Secret, signed)), nil
+}
+
+// Verify returns the claims of a token signed with one of the keys, issued less than the TTL ago.
+func (s *BeaconSigner) Verify(token string, now time.Time) (*models.BeaconClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrBeaconInvalid
+	}
+
+	keyIndex := slices.IndexFunc(s.keys, func(key models.BeaconKey) bool { return key.ID == parts[0] })
+	if keyIndex < 0 {
+		return nil, ErrBeaconInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, signature(s.keys[keyIndex].Secret, parts[0]+"."+parts[1])) {
+		return nil, ErrBeaconInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrBeaconInvalid
+	}
+
+	var claims models.BeaconClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Nonce == "" ||
+		!slices.Contains(constants.EventTypes, claims.Type) {
+		return nil, ErrBeaconInvalid
+	}
+
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if issuedAt.After(now.Add(beaconClockSkew)) {
+		return nil, ErrBeaconInvalid
+	}
+
+	if !now.Before(s.ExpiresAt(&claims)) {
+		return nil, ErrBeaconExpired
+	}
+
+	return &claims, nil
+}
+
+// ExpiresAt is when the token of the claims stops being accepted.
+func (s *BeaconSigner) ExpiresAt(claims *models.BeaconClaims) time.Time {
+	return time.Unix(claims.IssuedAt, 0).Add(s.ttl)
+}
+
+func signature(secret []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return mac.Sum(nil)
+}
+
+// beaconKeys reads BEACON_KEYS, comma separated id:secret pairs with the signing key first. Rotating the key means
+// putting the new one first and dropping the old one once the tokens it signed have expired.
+func beaconKeys() ([]models.BeaconKey, error) {
+	var keys []models.BeaconKey
+	for _, pair := range strings.Split(os.Getenv("BEACON_KEYS"), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		id, secret, ok := strings.Cut(pair, ":")
+		if !ok || id == "" || strings.Contains(id, ".") {
+			return nil, errors.New("BEACON_KEYS must be comma separated id:secret pairs, ids without dots")
+		}
+
+		if len(secret) < minBeaconSecret {
+			return nil, errors.New("the secret of beacon key " + id + " must have at least 32 characters")
+		}
+
+		keys = append(keys, models.BeaconKey{ID: id, Secret: []byte(secret)})
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,358 @@
+package helpers
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// country is an entry of the ISO 3166 registry. Its canonical value is the lower cased alpha-2 code, the one the
+// GeoIP database gives. The time zone is the one daypart rules are evaluated in when the request has no tz, countries
+// spanning several zones get their most populated one.
+type country struct {
+	code     string
+	alpha3   string
+	timezone string
+	names    []string
+}
+
+var countryRegistry = []country{
+	{"ad", "and", "Europe/Andorra", []string{"Andorra"}},
+	{"ae", "are", "Asia/Dubai", []string{"United Arab Emirates", "UAE", "Emirates"}},
+	{"af", "afg", "Asia/Kabul", []string{"Afghanistan"}},
+	{"ag", "atg", "America/Antigua", []string{"Antigua and Barbuda", "Antigua"}},
+	{"ai", "aia", "America/Anguilla", []string{"Anguilla"}},
+	{"al", "alb", "Europe/Tirane", []string{"Albania"}},
+	{"am", "arm", "Asia/Yerevan", []string{"Armenia"}},
+	{"ao", "ago", "Africa/Luanda", []string{"Angola"}},
+	{"aq", "ata", "Antarctica/McMurdo", []string{"Antarctica"}},
+	{"ar", "arg", "America/Argentina/Buenos_Aires", []string{"Argentina"}},
+	{"as", "asm", "Pacific/Pago_Pago", []string{"American Samoa"}},
+	{"at", "aut", "Europe/Vienna", []string{"Austria"}},
+	{"au", "aus", "Australia/Sydney", []string{"Australia"}},
+	{"aw", "abw", "America/Aruba", []string{"Aruba"}},
+	{"ax", "ala", "Europe/Mariehamn", []string{"Aland Islands", "Åland Islands"}},
+	{"az", "aze", "Asia/Baku", []string{"Azerbaijan"}},
+	{"ba", "bih", "Europe/Sarajevo", []string{"Bosnia and Herzegovina", "Bosnia"}},
+	{"bb", "brb", "America/Barbados", []string{"Barbados"}},
+	{"bd", "bgd", "Asia/Dhaka", []string{"Bangladesh"}},
+	{"be", "bel", "Europe/Brussels", []string{"Belgium"}},
+	{"bf", "bfa", "Africa/Ouagadougou", []string{"Burkina Faso"}},
+	{"bg", "bgr", "Europe/Sofia", []string{"Bulgaria"}},
+	{"bh", "bhr", "Asia/Bahrain", []string{"Bahrain"}},
+	{"bi", "bdi", "Africa/Bujumbura", []string{"Burundi"}},
+	{"bj", "ben", "Africa/Porto-Novo", []string{"Benin"}},
+	{"bl", "blm", "America/St_Barthelemy", []string{"Saint Barthelemy", "Saint Barthélemy"}},
+	{"bm", "bmu", "Atlantic/Bermuda", []string{"Bermuda"}},
+	{"bn", "brn", "Asia/Brunei", []string{"Brunei", "Brunei Darussalam"}},
+	{"bo", "bol", "America/La_Paz", []string{"Bolivia"}},
+	{"bq", "bes", "America/Kralendijk", []string{"Caribbean Netherlands", "Bonaire"}},
+	{"br", "bra", "America/Sao_Paulo", []string{"Brazil"}},
+	{"bs", "bhs", "America/Nassau", []string{"Bahamas", "The Bahamas"}},
+	{"bt", "btn", "Asia/Thimphu", []string{"Bhutan"}},
+	{"bv", "bvt", "Europe/Oslo", []string{"Bouvet Island"}},
+	{"bw", "bwa", "Africa/Gaborone", []string{"Botswana"}},
+	{"by", "blr", "Europe/Minsk", []string{"Belarus"}},
+	{"bz", "blz", "America/Belize", []string{"Belize"}},
+	{"ca", "can", "America/Toronto", []string{"Canada"}},
+	{"cc", "cck", "Indian/Cocos", []string{"Cocos Islands", "Cocos (Keeling) Islands"}},
+	{"cd", "cod", "Africa/Kinshasa", []string{"DR Congo", "Democratic Republic of the Congo", "Congo-Kinshasa"}},
+	{"cf", "caf", "Africa/Bangui", []string{"Central African Republic"}},
+	{"cg", "cog", "Africa/Brazzaville", []string{"Congo", "Republic of the Congo", "Congo-Brazzaville"}},
+	{"ch", "che", "Europe/Zurich", []string{"Switzerland"}},
+	{"ci", "civ", "Africa/Abidjan", []string{"Ivory Coast", "Cote d'Ivoire", "Côte d'Ivoire"}},
+	{"ck", "cok", "Pacific/Rarotonga", []string{"Cook Islands"}},
+	{"cl", "chl", "America/Santiago", []string{"Chile"}},
+	{"cm", "cmr", "Africa/Douala", []string{"Cameroon"}},
+	{"cn", "chn", "Asia/Shanghai", []string{"China"}},
+	{"co", "col", "America/Bogota", []string{"Colombia"}},
+	{"cr", "cri", "America/Costa_Rica", []string{"Costa Rica"}},
+	{"cu", "cub", "America/Havana", []string{"Cuba"}},
+	{"cv", "cpv", "Atlantic/Cape_Verde", []string{"Cape Verde", "Cabo Verde"}},
+	{"cw", "cuw", "America/Curacao", []string{"Curacao", "Curaçao"}},
+	{"cx", "cxr", "Indian/Christmas", []string{"Christmas Island"}},
+	{"cy", "cyp", "Asia/Nicosia", []string{"Cyprus"}},
+	{"cz", "cze", "Europe/Prague", []string{"Czechia", "Czech Republic"}},
+	{"de", "deu", "Europe/Berlin", []string{"Germany"}},
+	{"dj", "dji", "Africa/Djibouti", []string{"Djibouti"}},
+	{"dk", "dnk", "Europe/Copenhagen", []string{"Denmark"}},
+	{"dm", "dma", "America/Dominica", []string{"Dominica"}},
+	{"do", "dom", "America/Santo_Domingo", []string{"Dominican Republic"}},
+	{"dz", "dza", "Africa/Algiers", []string{"Algeria"}},
+	{"ec", "ecu", "America/Guayaquil", []string{"Ecuador"}},
+	{"ee", "est", "Europe/Tallinn", []string{"Estonia"}},
+	{"eg", "egy", "Africa/Cairo", []string{"Egypt"}},
+	{"eh", "esh", "Africa/El_Aaiun", []string{"Western Sahara"}},
+	{"er", "eri", "Africa/Asmara", []string{"Eritrea"}},
+	{"es", "esp", "Europe/Madrid", []string{"Spain"}},
+	{"et", "eth", "Africa/Addis_Ababa", []string{"Ethiopia"}},
+	{"fi", "fin", "Europe/Helsinki", []string{"Finland"}},
+	{"fj", "fji", "Pacific/Fiji", []string{"Fiji"}},
+	{"fk", "flk", "Atlantic/Stanley", []string{"Falkland Islands"}},
+	{"fm", "fsm", "Pacific/Pohnpei", []string{"Micronesia"}},
+	{"fo", "fro", "Atlantic/Faroe", []string{"Faroe Islands"}},
+	{"fr", "fra", "Europe/Paris", []string{"France"}},
+	{"ga", "gab", "Africa/Libreville", []string{"Gabon"}},
+	{"gb", "gbr", "Europe/London", []string{"United Kingdom", "UK", "Great Britain", "Britain", "England"}},
+	{"gd", "grd", "America/Grenada", []string{"Grenada"}},
+	{"ge", "geo", "Asia/Tbilisi", []string{"Georgia"}},
+	{"gf", "guf", "America/Cayenne", []string{"French Guiana"}},
+	{"gg", "ggy", "Europe/Guernsey", []string{"Guernsey"}},
+	{"gh", "gha", "Africa/Accra", []string{"Ghana"}},
+	{"gi", "gib", "Europe/Gibraltar", []string{"Gibraltar"}},
+	{"gl", "grl", "America/Nuuk", []string{"Greenland"}},
+	{"gm", "gmb", "Africa/Banjul", []string{"Gambia", "The Gambia"}},
+	{"gn", "gin", "Africa/Conakry", []string{"Guinea"}},
+	{"gp", "glp", "America/Guadeloupe", []string{"Guadeloupe"}},
+	{"gq", "gnq", "Africa/Malabo", []string{"Equatorial Guinea"}},
+	{"gr", "grc", "Europe/Athens", []string{"Greece"}},
+	{"gs", "sgs", "Atlantic/South_Georgia", []string{"South Georgia and the South Sandwich Islands", "South Georgia"}},
+	{"gt", "gtm", "America/Guatemala", []string{"Guatemala"}},
+	{"gu", "gum", "Pacific/Guam", []string{"Guam"}},
+	{"gw", "gnb", "Africa/Bissau", []string{"Guinea-Bissau"}},
+	{"gy", "guy", "America/Guyana", []string{"Guyana"}},
+	{"hk", "hkg", "Asia/Hong_Kong", []string{"Hong Kong"}},
+	{"hm", "hmd", "Indian/Kerguelen", []string{"Heard Island and McDonald Islands"}},
+	{"hn", "hnd", "America/Tegucigalpa", []string{"Honduras"}},
+	{"hr", "hrv", "Europe/Zagreb", []string{"Croatia"}},
+	{"ht", "hti", "America/Port-au-Prince", []string{"Haiti"}},
+	{"hu", "hun", "Europe/Budapest", []string{"Hungary"}},
+	{"id", "idn", "Asia/Jakarta", []string{"Indonesia"}},
+	{"ie", "irl", "Europe/Dublin", []string{"Ireland"}},
+	{"il", "isr", "Asia/Jerusalem", []string{"Israel"}},
+	{"im", "imn", "Europe/Isle_of_Man", []string{"Isle of Man"}},
+	{"in", "ind", "Asia/Kolkata", []string{"India"}},
+	{"io", "iot", "Indian/Chagos", []string{"British Indian Ocean Territory"}},
+	{"iq", "irq", "Asia/Baghdad", []string{"Iraq"}},
+	{"ir", "irn", "Asia/Tehran", []string{"Iran"}},
+	{"is", "isl", "Atlantic/Reykjavik", []string{"Iceland"}},
+	{"it", "ita", "Europe/Rome", []string{"Italy"}},
+	{"je", "jey", "Europe/Jersey", []string{"Jersey"}},
+	{"jm", "jam", "America/Jamaica", []string{"Jamaica"}},
+	{"jo", "jor", "Asia/Amman", []string{"Jordan"}},
+	{"jp", "jpn", "Asia/Tokyo", []string{"Japan"}},
+	{"ke", "ken", "Africa/Nairobi", []string{"Kenya"}},
+	{"kg", "kgz", "Asia/Bishkek", []string{"Kyrgyzstan"}},
+	{"kh", "khm", "Asia/Phnom_Penh", []string{"Cambodia"}},
+	{"ki", "kir", "Pacific/Tarawa", []string{"Kiribati"}},
+	{"km", "com", "Indian/Comoro", []string{"Comoros"}},
+	{"kn", "kna", "America/St_Kitts", []string{"Saint Kitts and Nevis"}},
+	{"kp", "prk", "Asia/Pyongyang", []string{"North Korea", "DPRK"}},
+	{"kr", "kor", "Asia/Seoul", []string{"South Korea", "Korea"}},
+	{"kw", "kwt", "Asia/Kuwait", []string{"Kuwait"}},
+	{"ky", "cym", "America/Cayman", []string{"Cayman Islands"}},
+	{"kz", "kaz", "Asia/Almaty", []string{"Kazakhstan"}},
+	{"la", "lao", "Asia/Vientiane", []string{"Laos"}},
+	{"lb", "lbn", "Asia/Beirut", []string{"Lebanon"}},
+	{"lc", "lca", "America/St_Lucia", []string{"Saint Lucia"}},
+	{"li", "lie", "Europe/Vaduz", []string{"Liechtenstein"}},
+	{"lk", "lka", "Asia/Colombo", []string{"Sri Lanka"}},
+	{"lr", "lbr", "Africa/Monrovia", []string{"Liberia"}},
+	{"ls", "lso", "Africa/Maseru", []string{"Lesotho"}},
+	{"lt", "ltu", "Europe/Vilnius", []string{"Lithuania"}},
+	{"lu", "lux", "Europe/Luxembourg", []string{"Luxembourg"}},
+	{"lv", "lva", "Europe/Riga", []string{"Latvia"}},
+	{"ly", "lby", "Africa/Tripoli", []string{"Libya"}},
+	{"ma", "mar", "Africa/Casablanca", []string{"Morocco"}},
+	{"mc", "mco", "Europe/Monaco", []string{"Monaco"}},
+	{"md", "mda", "Europe/Chisinau", []string{"Moldova"}},
+	{"me", "mne", "Europe/Podgorica", []string{"Montenegro"}},
+	{"mf", "maf", "America/Marigot", []string{"Saint Martin"}},
+	{"mg", "mdg", "Indian/Antananarivo", []string{"Madagascar"}},
+	{"mh", "mhl", "Pacific/Majuro", []string{"Marshall Islands"}},
+	{"mk", "mkd", "Europe/Skopje", []string{"North Macedonia", "Macedonia"}},
+	{"ml", "mli", "Africa/Bamako", []string{"Mali"}},
+	{"mm", "mmr", "Asia/Yangon", []string{"Myanmar", "Burma"}},
+	{"mn", "mng", "Asia/Ulaanbaatar", []string{"Mongolia"}},
+	{"mo", "mac", "Asia/Macau", []string{"Macau", "Macao"}},
+	{"mp", "mnp", "Pacific/Saipan", []string{"Northern Mariana Islands"}},
+	{"mq", "mtq", "America/Martinique", []string{"Martinique"}},
+	{"mr", "mrt", "Africa/Nouakchott", []string{"Mauritania"}},
+	{"ms", "msr", "America/Montserrat", []string{"Montserrat"}},
+	{"mt", "mlt", "Europe/Malta", []string{"Malta"}},
+	{"mu", "mus", "Indian/Mauritius", []string{"Mauritius"}},
+	{"mv", "mdv", "Indian/Maldives", []string{"Maldives"}},
+	{"mw", "mwi", "Africa/Blantyre", []string{"Malawi"}},
+	{"mx", "mex", "America/Mexico_City", []string{"Mexico"}},
+	{"my", "mys", "Asia/Kuala_Lumpur", []string{"Malaysia"}},
+	{"mz", "moz", "Africa/Maputo", []string{"Mozambique"}},
+	{"na", "nam", "Africa/Windhoek", []string{"Namibia"}},
+	{"nc", "ncl", "Pacific/Noumea", []string{"New Caledonia"}},
+	{"ne", "ner", "Africa/Niamey", []string{"Niger"}},
+	{"nf", "nfk", "Pacific/Norfolk", []string{"Norfolk Island"}},
+	{"ng", "nga", "Africa/Lagos", []string{"Nigeria"}},
+	{"ni", "nic", "America/Managua", []string{"Nicaragua"}},
+	{"nl", "nld", "Europe/Amsterdam", []string{"Netherlands", "The Netherlands", "Holland"}},
+	{"no", "nor", "Europe/Oslo", []string{"Norway"}},
+	{"np", "npl", "Asia/Kathmandu", []string{"Nepal"}},
+	{"nr", "nru", "Pacific/Nauru", []string{"Nauru"}},
+	{"nu", "niu", "Pacific/Niue", []string{"Niue"}},
+	{"nz", "nzl", "Pacific/Auckland", []string{"New Zealand"}},
+	{"om", "omn", "Asia/Muscat", []string{"Oman"}},
+	{"pa", "pan", "America/Panama", []string{"Panama"}},
+	{"pe", "per", "America/Lima", []string{"Peru"}},
+	{"pf", "pyf", "Pacific/Tahiti", []string{"French Polynesia"}},
+	{"pg", "png", "Pacific/Port_Moresby", []string{"Papua New Guinea"}},
+	{"ph", "phl", "Asia/Manila", []string{"Philippines"}},
+	{"pk", "pak", "Asia/Karachi", []string{"Pakistan"}},
+	{"pl", "pol", "Europe/Warsaw", []string{"Poland"}},
+	{"pm", "spm", "America/Miquelon", []string{"Saint Pierre and Miquelon"}},
+	{"pn", "pcn", "Pacific/Pitcairn", []string{"Pitcairn Islands"}},
+	{"pr", "pri", "America/Puerto_Rico", []string{"Puerto Rico"}},
+	{"ps", "pse", "Asia/Gaza", []string{"Palestine"}},
+	{"pt", "prt", "Europe/Lisbon", []string{"Portugal"}},
+	{"pw", "plw", "Pacific/Palau", []string{"Palau"}},
+	{"py", "pry", "America/Asuncion", []string{"Paraguay"}},
+	{"qa", "qat", "Asia/Qatar", []string{"Qatar"}},
+	{"re", "reu", "Indian/Reunion", []string{"Reunion", "Réunion"}},
+	{"ro", "rou", "Europe/Bucharest", []string{"Romania"}},
+	{"rs", "srb", "Europe/Belgrade", []string{"Serbia"}},
+	{"ru", "rus", "Europe/Moscow", []string{"Russia", "Russian Federation"}},
+	{"rw", "rwa", "Africa/Kigali", []string{"Rwanda"}},
+	{"sa", "sau", "Asia/Riyadh", []string{"Saudi Arabia"}},
+	{"sb", "slb", "Pacific/Guadalcanal", []string{"Solomon Islands"}},
+	{"sc", "syc", "Indian/Mahe", []string{"Seychelles"}},
+	{"sd", "sdn", "Africa/Khartoum", []string{"Sudan"}},
+	{"se", "swe", "Europe/Stockholm", []string{"Sweden"}},
+	{"sg", "sgp", "Asia/Singapore", []string{"Singapore"}},
+	{"sh", "shn", "Atlantic/St_Helena", []string{"Saint Helena"}},
+	{"si", "svn", "Europe/Ljubljana", []string{"Slovenia"}},
+	{"sj", "sjm", "Arctic/Longyearbyen", []string{"Svalbard and Jan Mayen", "Svalbard"}},
+	{"sk", "svk", "Europe/Bratislava", []string{"Slovakia"}},
+	{"sl", "sle", "Africa/Freetown", []string{"Sierra Leone"}},
+	{"sm", "smr", "Europe/San_Marino", []string{"San Marino"}},
+	{"sn", "sen", "Africa/Dakar", []string{"Senegal"}},
+	{"so", "som", "Africa/Mogadishu", []string{"Somalia"}},
+	{"sr", "sur", "America/Paramaribo", []string{"Suriname"}},
+	{"ss", "ssd", "Africa/Juba", []string{"South Sudan"}},
+	{"st", "stp", "Africa/Sao_Tome", []string{"Sao Tome and Principe", "São Tomé and Príncipe"}},
+	{"sv", "slv", "America/El_Salvador", []string{"El Salvador"}},
+	{"sx", "sxm", "America/Lower_Princes", []string{"Sint Maarten"}},
+	{"sy", "syr", "Asia/Damascus", []string{"Syria"}},
+	{"sz", "swz", "Africa/Mbabane", []string{"Eswatini", "Swaziland"}},
+	{"tc", "tca", "America/Grand_Turk", []string{"Turks and Caicos Islands"}},
+	{"td", "tcd", "Africa/Ndjamena", []string{"Chad"}},
+	{"tf", "atf", "Indian/Kerguelen", []string{"French Southern Territories"}},
+	{"tg", "tgo", "Africa/Lome", []string{"Togo"}},
+	{"th", "tha", "Asia/Bangkok", []string{"Thailand"}},
+	{"tj", "tjk", "Asia/Dushanbe", []string{"Tajikistan"}},
+	{"tk", "tkl", "Pacific/Fakaofo", []string{"Tokelau"}},
+	{"tl", "tls", "Asia/Dili", []string{"Timor-Leste", "East Timor"}},
+	{"tm", "tkm", "Asia/Ashgabat", []string{"Turkmenistan"}},
+	{"tn", "tun", "Africa/Tunis", []string{"Tunisia"}},
+	{"to", "ton", "Pacific/Tongatapu", []string{"Tonga"}},
+	{"tr", "tur", "Europe/Istanbul", []string{"Turkey", "Türkiye", "Turkiye"}},
+	{"tt", "tto", "America/Port_of_Spain", []string{"Trinidad and Tobago"}},
+	{"tv", "tuv", "Pacific/Funafuti", []string{"Tuvalu"}},
+	{"tw", "twn", "Asia/Taipei", []string{"Taiwan"}},
+	{"tz", "tza", "Africa/Dar_es_Salaam", []string{"Tanzania"}},
+	{"ua", "ukr", "Europe/Kyiv", []string{"Ukraine"}},
+	{"ug", "uga", "Africa/Kampala", []string{"Uganda"}},
+	{"um", "umi", "Pacific/Midway", []string{"United States Minor Outlying Islands"}},
+	{"us", "usa", "America/New_York", []string{"United States", "United States of America", "America"}},
+	{"uy", "ury", "America/Montevideo", []string{"Uruguay"}},
+	{"uz", "uzb", "Asia/Tashkent", []string{"Uzbekistan"}},
+	{"va", "vat", "Europe/Vatican", []string{"Vatican City", "Holy See", "Vatican"}},
+	{"vc", "vct", "America/St_Vincent", []string{"Saint Vincent and the Grenadines"}},
+	{"ve", "ven", "America/Caracas", []string{"Venezuela"}},
+	{"vg", "vgb", "America/Tortola", []string{"British Virgin Islands"}},
+	{"vi", "vir", "America/St_Thomas", []string{"US Virgin Islands", "United States Virgin Islands"}},
+	{"vn", "vnm", "Asia/Ho_Chi_Minh", []string{"Vietnam", "Viet Nam"}},
+	{"vu", "vut", "Pacific/Efate", []string{"Vanuatu"}},
+	{"wf", "wlf", "Pacific/Wallis", []string{"Wallis and Futuna"}},
+	{"ws", "wsm", "Pacific/Apia", []string{"Samoa"}},
+	{"xk", "xkx", "Europe/Belgrade", []string{"Kosovo"}},
+	{"ye", "yem", "Asia/Aden", []string{"Yemen"}},
+	{"yt", "myt", "Indian/Mayotte", []string{"Mayotte"}},
+	{"za", "zaf", "Africa/Johannesburg", []string{"South Africa"}},
+	{"zm", "zmb", "Africa/Lusaka", []string{"Zambia"}},
+	{"zw", "zwe", "Africa/Harare", []string{"Zimbabwe"}},
+}
+
+// countries finds the entries of the registry by their codes and by their names written without spaces or
+// punctuation, like northkorea, which is how names are written in the rules.
+var countries = indexCountries()
+
+func indexCountries() map[string]*country {
+	index := make(map[string]*country, len(countryRegistry)*4)
+
+	for i := range countryRegistry {
+		entry := &countryRegistry[i]
+
+		index[entry.code] = entry
+		index[entry.alpha3] = entry
+
+		for _, name := range entry.names {
+			index[countryKey(name)] = entry
+		}
+	}
+
+	return index
+}
+
+// countryKey lower cases the letters of the value and drops everything else, so that "North Korea", "north-korea" and
+// northkorea are the same key.
+func countryKey(value string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, value)
+}
+
+// CanonicalCountry returns the lower cased ISO 3166 alpha-2 code of a country given by its alpha-2 or alpha-3 code or
+// by a common name, like ca for CA, CAN or Canada. Values outside the registry and globs are returned lower cased, and
+// regular expressions as they are.
+func CanonicalCountry(value string) string {
+	value = strings.TrimSpace(value)
+	if IsRegex(value) {
+		return value
+	}
+
+	value = strings.ToLower(value)
+	if IsPattern(value) {
+		return value
+	}
+
+	if entry, ok := countries[countryKey(value)]; ok {
+		return entry.code
+	}
+
+	return value
+}
+
+// CanonicalCountries returns the canonical values of the countries, dropping the duplicates the aliases make.
+func CanonicalCountries(values []string) []string {
+	canonical := make([]string, 0, len(values))
+
+	for _, value := range values {
+		value = CanonicalCountry(value)
+		if !slices.Contains(canonical, value) {
+			canonical = append(canonical, value)
+		}
+	}
+
+	return canonical
+}
+
+// CountryAliases lists the ways rules saved before countries were canonical may write the country: its codes and its
+// names, lower cased with and without spaces. A country outside the registry is only written one way.
+func CountryAliases(value string) []string {
+	entry, ok := countries[countryKey(value)]
+	if !ok {
+		return []string{value}
+	}
+
+	aliases := []string{entry.code, entry.alpha3}
+	for _, name := range entry.names {
+		for _, alias := range []string{countryKey(name), strings.ToLower(name)} {
+			if !slices.Contains(aliases, alias) {
+				aliases = append(aliases, alias)
+			}
+		}
+	}
+
+	return aliases
+}
@@ -0,0 +1,294 @@
+package helpers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+var (
+	// CampaignsHeader lists the columns of a campaigns file, the required ones first. Files without the optional
+	// schedule, ranking, frequency cap and impression goal columns hold unscheduled and uncapped campaigns of
+	// priority 0 without goals.
+	CampaignsHeader = []string{"CampaignID", "Name", "Image", "CTA", "Status", "StartAt", "EndAt", "Timezone",
+		"Priority", "Weight", "FrequencyCap", "TotalGoal", "DailyGoal"}
+	// RulesHeader lists the columns of a rules file, all required.
+	RulesHeader = []string{"CampaignID", "Dimension", "Include", "Exclude"}
+)
+
+const campaignsRequiredColumns = 5
+
+// CampaignRow is a campaign along with the row of the file defining it.
+type CampaignRow struct {
+	Row      int
+	Campaign *models.Campaign
+}
+
+// CampaignsCSV is what a campaigns file holds: the campaigns of its valid rows, in their order, the errors of the
+// other rows and the columns of its header.
+type CampaignsCSV struct {
+	Columns   []string
+	Campaigns []CampaignRow
+	Errors    []models.ImportError
+}
+
+// HasColumn tells whether the file has the column, the campaigns of a file without it leaving the field empty.
+func (c *CampaignsCSV) HasColumn(column string) bool {
+	return slices.Contains(c.Columns, column)
+}
+
+// RulesRow is the rules and expression of a campaign along with the first row of the file defining them.
+type RulesRow struct {
+	Row  int
+	Rule *models.TargetingRule
+}
+
+// RulesCSV is what a rules file holds: the rules of its valid rows grouped by campaign, in the order the campaigns
+// first appear, and the errors of the other rows.
+type RulesCSV struct {
+	Rules  []RulesRow
+	Errors []models.ImportError
+}
+
+// ReadCampaignsCSV reads a campaigns file in the stores/testdata layout. The columns are matched by the names of the
+// header, in any order and case, and every row is normalized and validated like a campaign of the API, an invalid
+// one being reported among the errors. A file that can't be parsed, or without header, is an error.
+func ReadCampaignsCSV(r io.Reader) (*CampaignsCSV, error) {
+	records, columns, err := readCSV(r, constants.EntityCampaigns, CampaignsHeader, campaignsRequiredColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &CampaignsCSV{Errors: []models.ImportError{}}
+	for _, name := range CampaignsHeader {
+		if _, ok := columns[name]; ok {
+			file.Columns = append(file.Columns, name)
+		}
+	}
+
+	rows := make(map[string]int)
+
+	for i, record := range records {
+		row := i + 2
+
+		if len(record) != len(columns) {
+			file.Errors = append(file.Errors, models.ImportError{File: constants.EntityCampaigns, Row: row,
+				Reason: fmt.Sprintf("Expected %d columns, found %d", len(columns), len(record))})
+			continue
+		}
+
+		campaign, err := parseCampaign(record, columns)
+		if err != nil {
+			file.Errors = append(file.Errors, models.ImportError{File: constants.EntityCampaigns, Row: row,
+				CampaignID: campaign.CampaignID, Reason: err.Error()})
+			continue
+		}
+
+		if existing, ok := rows[campaign.CampaignID]; ok {
+			file.Errors = append(file.Errors, models.ImportError{File: constants.EntityCampaigns, Row: row,
+				CampaignID: campaign.CampaignID, Reason: fmt.Sprintf("Campaign is already defined in row %d", existing)})
+			continue
+		}
+
+		rows[campaign.CampaignID] = row
+		file.Campaigns = append(file.Campaigns, CampaignRow{Row: row, Campaign: campaign})
+	}
+
+	return file, nil
+}
+
+// parseCampaign reads the campaign of a row, along with its optional schedule, ranking, frequency cap and impression
+// goal, the times without an offset being in the timezone. The campaign is returned even when invalid, for its id.
+func parseCampaign(record []string, columns map[string]int) (*models.Campaign, error) {
+	value := func(column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	campaign := &models.Campaign{CampaignID: value("CampaignID"), Name: value("Name"), Image: value("Image"),
+		CTA: value("CTA"), Status: value("Status"), Timezone: value("Timezone")}
+	NormalizeCampaign(campaign)
+
+	var err error
+	if campaign.StartAt, err = ParseScheduleTime(value("StartAt"), campaign.Timezone); err != nil {
+		return campaign, invalidParam("Parameter start_at is invalid: " + err.Error())
+	}
+
+	if campaign.EndAt, err = ParseScheduleTime(value("EndAt"), campaign.Timezone); err != nil {
+		return campaign, invalidParam("Parameter end_at is invalid: " + err.Error())
+	}
+
+	if priority := value("Priority"); priority != "" {
+		if campaign.Priority, err = strconv.Atoi(priority); err != nil {
+			return campaign, invalidParam("Parameter priority must be an integer")
+		}
+	}
+
+	if weight := value("Weight"); weight != "" {
+		if campaign.Weight, err = strconv.Atoi(weight); err != nil {
+			return campaign, invalidParam("Parameter weight must be an integer")
+		}
+	}
+
+	if campaign.FrequencyCap, err = ParseFrequencyCap(strings.ToLower(value("FrequencyCap"))); err != nil {
+		return campaign, invalidParam("Parameter frequency_cap is invalid: " + err.Error())
+	}
+
+	if campaign.ImpressionGoal, err = ParseImpressionGoal(value("TotalGoal"), value("DailyGoal")); err != nil {
+		return campaign, invalidParam("Parameter impression_goal is invalid: " + err.Error())
+	}
+
+	return campaign, ValidateCampaign(campaign)
+}
+
+// ReadRulesCSV reads a rules file in the stores/testdata layout, include and exclude values being pipe separated. The
+// include column of an expression row holds the expression of the campaign, and the one of a daypart row its windows.
+// The columns are matched like the ones of a campaigns file, and the rules are normalized and validated like the
+// rules of the API.
+func ReadRulesCSV(r io.Reader) (*RulesCSV, error) {
+	records, columns, err := readCSV(r, constants.EntityRules, RulesHeader, len(RulesHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	file := &RulesCSV{Errors: []models.ImportError{}}
+	positions := make(map[string]int)
+
+	for i, record := range records {
+		row := i + 2
+
+		if len(record) != len(columns) {
+			file.Errors = append(file.Errors, models.ImportError{File: constants.EntityRules, Row: row,
+				Reason: fmt.Sprintf("Expected %d columns, found %d", len(columns), len(record))})
+			continue
+		}
+
+		campaignID := strings.ToLower(strings.TrimSpace(record[columns["CampaignID"]]))
+		if campaignID == "" {
+			file.Errors = append(file.Errors, models.ImportError{File: constants.EntityRules, Row: row,
+				Reason: "Parameter campaign_id is required"})
+			continue
+		}
+
+		position, ok := positions[campaignID]
+
+		rule := &models.TargetingRule{CampaignID: campaignID, Rules: []models.Rule{}}
+		if ok {
+			rule = file.Rules[position].Rule
+		}
+
+		err := parseRule(rule, record[columns["Dimension"]], record[columns["Include"]], record[columns["Exclude"]])
+		if err != nil {
+			file.Errors = append(file.Errors, models.ImportError{File: constants.EntityRules, Row: row,
+				CampaignID: campaignID, Reason: err.Error()})
+			continue
+		}
+
+		if !ok {
+			positions[campaignID] = len(file.Rules)
+			file.Rules = append(file.Rules, RulesRow{Row: row, Rule: rule})
+		}
+	}
+
+	return file, nil
+}
+
+// parseRule adds the rule or expression of a row to the rules of its campaign, leaving them as they are when invalid.
+func parseRule(rule *models.TargetingRule, dimension, include, exclude string) error {
+	if strings.EqualFold(strings.TrimSpace(dimension), constants.Expression) {
+		if rule.Expression != "" {
+			return invalidParam("Dimension '" + constants.Expression + "' is defined more than once")
+		}
+
+		expression, err := NormalizeExpression(include)
+		if err != nil {
+			return err
+		}
+
+		rule.Expression = expression
+
+		return nil
+	}
+
+	added := []models.Rule{{Dimension: dimension, Include: SplitValues(include), Exclude: SplitValues(exclude)}}
+	NormalizeRules(added)
+
+	if added[0].Dimension == constants.Daypart {
+		windows, err := ParseTimeWindows(added[0].Include)
+		if err != nil {
+			return invalidParam("Parameter windows is invalid: " + err.Error())
+		}
+
+		added[0].Include, added[0].Windows = []string{}, windows
+	}
+
+	if err := ValidateRules(append(slices.Clone(rule.Rules), added...)); err != nil {
+		return err
+	}
+
+	rule.Rules = append(rule.Rules, added...)
+
+	return nil
+}
+
+// readCSV returns the records following the header, none when there is nothing to read, along with the positions of
+// the columns by their name in the expected header. The header must have the required columns and may have the
+// others, in any order and case.
+func readCSV(r io.Reader, file string, header []string, required int) ([][]string, map[string]int, error) {
+	if r == nil {
+		return nil, nil, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, invalidParam("Unable to parse " + file + " file: " + err.Error())
+	}
+
+	headerError := invalidParam("The " + file + " file must start with a header of the columns " +
+		strings.Join(header[:required], ","))
+	if required < len(header) {
+		headerError = invalidParam("The " + file + " file must start with a header of the columns " +
+			strings.Join(header[:required], ",") + " and optionally " + strings.Join(header[required:], ","))
+	}
+
+	if len(records) == 0 {
+		return nil, nil, headerError
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		index := slices.IndexFunc(header, func(column string) bool {
+			return strings.EqualFold(column, strings.TrimSpace(name))
+		})
+		if index < 0 {
+			return nil, nil, headerError
+		}
+
+		if _, ok := columns[header[index]]; ok {
+			return nil, nil, invalidParam("Column " + header[index] + " is repeated in the header of the " + file + " file")
+		}
+
+		columns[header[index]] = i
+	}
+
+	for _, column := range header[:required] {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, headerError
+		}
+	}
+
+	return records[1:], columns, nil
+}
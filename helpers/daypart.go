@@ -0,0 +1,126 @@
+package helpers
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// Location returns the time zone tz, or the one of the country when tz is empty, UTC for an unknown country.
+func Location(tz, country string) (*time.Location, error) {
+	if entry, ok := countries[countryKey(country)]; ok && tz == "" {
+		tz = entry.timezone
+	}
+
+	return time.LoadLocation(tz)
+}
+
+// Daypart names the hour of the local time, like "fri:18". Responses depending on daypart rules are the same within
+// it.
+func Daypart(t time.Time) string {
+	return constants.Weekdays[t.Weekday()] + ":" + strconv.Itoa(t.Hour())
+}
+
+// ParseTimeWindow reads a window written like FormatTimeWindow does, "mon,tue 18-22", where days also take ranges
+// like "mon-fri" and may be left out for every day.
+func ParseTimeWindow(value string) (models.TimeWindow, error) {
+	var window models.TimeWindow
+
+	fields := strings.Fields(strings.ToLower(value))
+	if len(fields) == 0 || len(fields) > 2 {
+		return window, errors.New("invalid window " + value + ", expected like mon-fri 18-22")
+	}
+
+	if len(fields) == 2 {
+		for _, days := range strings.Split(fields[0], ",") {
+			expanded, err := expandDays(days)
+			if err != nil {
+				return window, err
+			}
+
+			window.Days = append(window.Days, expanded...)
+		}
+	}
+
+	start, end, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return window, errors.New("invalid hours " + fields[len(fields)-1] + ", expected like 18-22")
+	}
+
+	var err error
+	if window.Start, err = strconv.Atoi(start); err != nil {
+		return window, errors.New("invalid hour " + start)
+	}
+
+	if window.End, err = strconv.Atoi(end); err != nil {
+		return window, errors.New("invalid hour " + end)
+	}
+
+	return window, nil
+}
+
+// ParseTimeWindows reads the windows of a daypart row of a rules file, which are in its include column.
+func ParseTimeWindows(values []string) ([]models.TimeWindow, error) {
+	windows := make([]models.TimeWindow, 0, len(values))
+
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		window, err := ParseTimeWindow(value)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+// expandDays lists the days of a day or a range of days, a range may wrap around the week like fri-mon.
+func expandDays(days string) ([]string, error) {
+	first, last, isRange := strings.Cut(days, "-")
+	if !isRange {
+		last = first
+	}
+
+	from, to := slices.Index(constants.Weekdays, first), slices.Index(constants.Weekdays, last)
+	if from < 0 || to < 0 {
+		return nil, errors.New("invalid days " + days + ", expected names like mon or ranges like mon-fri")
+	}
+
+	var expanded []string
+	for day := from; ; day = (day + 1) % len(constants.Weekdays) {
+		expanded = append(expanded, constants.Weekdays[day])
+		if day == to {
+			return expanded, nil
+		}
+	}
+}
+
+// FormatTimeWindows writes the windows the way they are in the include column of a daypart row, pipe separated.
+func FormatTimeWindows(windows []models.TimeWindow) string {
+	formatted := make([]string, 0, len(windows))
+	for _, window := range windows {
+		formatted = append(formatted, FormatTimeWindow(window))
+	}
+
+	return strings.Join(formatted, "|")
+}
+
+// FormatTimeWindow writes the window as "mon,tue 18-22", or "18-22" when it covers every day.
+func FormatTimeWindow(window models.TimeWindow) string {
+	hours := strconv.Itoa(window.Start) + "-" + strconv.Itoa(window.End)
+	if len(window.Days) == 0 {
+		return hours
+	}
+
+	return strings.Join(window.Days, ",") + " " + hours
+}
@@ -0,0 +1,456 @@
+package helpers
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+)
+
+// Expression is a boolean targeting expression, like (country = us and os = ios) or country in (ca, mx). It is
+// written with and, or, not and parentheses over conditions on the dimensions.
+type Expression interface {
+	// Evaluate tells whether the expression holds, given whether each of its conditions does.
+	Evaluate(holds func(Condition) bool) bool
+	// Conditions lists the conditions of the expression, in the order they are written.
+	Conditions() []Condition
+	// String writes the expression in its canonical form, lower cased with the parentheses it needs.
+	String() string
+}
+
+// Condition compares the value of a dimension of the request with the values of the expression. With = and in the
+// value is one of them, with != and not in it is none of them, and version dimensions also compare with <, <=, > and
+// >= to a single version.
+type Condition struct {
+	Dimension string
+	Operator  string
+	Values    []string
+}
+
+const (
+	OperatorEqual    = "="
+	OperatorNotEqual = "!="
+	OperatorIn       = "in"
+	OperatorNotIn    = "not in"
+)
+
+var comparisonOperators = []string{"<", "<=", ">", ">="}
+
+// ParseExpression reads an expression, the dimensions and keywords being case insensitive and the values lower cased
+// like the values of the rules, but regular expressions, and countries canonical. Values with spaces, parentheses,
+// commas or operators are quoted with ' or ". Nothing is cached here, the targeting index keeps the expressions it
+// parses in its snapshot.
+func ParseExpression(expression string) (Expression, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &expressionParser{tokens: tokens}
+
+	parsed, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.position < len(p.tokens) {
+		return nil, errors.New("unexpected " + p.tokens[p.position].text)
+	}
+
+	return parsed, nil
+}
+
+type expressionToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenize splits the expression into parentheses, commas, operators, quoted values and words.
+func tokenize(expression string) ([]expressionToken, error) {
+	var tokens []expressionToken
+
+	for i := 0; i < len(expression); {
+		char := expression[i]
+
+		switch {
+		case unicode.IsSpace(rune(char)):
+			i++
+		case char == '(' || char == ')' || char == ',' || char == '=':
+			tokens = append(tokens, expressionToken{text: string(char)})
+			i++
+		case char == '!' || char == '<' || char == '>':
+			operator := string(char)
+			if i+1 < len(expression) && expression[i+1] == '=' {
+				operator += "="
+			}
+
+			if operator == "!" {
+				return nil, errors.New("unexpected !, write != or not")
+			}
+
+			tokens = append(tokens, expressionToken{text: operator})
+			i += len(operator)
+		case char == '\'' || char == '"':
+			end := strings.IndexByte(expression[i+1:], char)
+			if end < 0 {
+				return nil, errors.New("unterminated quoted value " + expression[i:])
+			}
+
+			tokens = append(tokens, expressionToken{text: expression[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		default:
+			end := i
+			for end < len(expression) && !unicode.IsSpace(rune(expression[end])) &&
+				!strings.ContainsRune("(),=!<>'\"", rune(expression[end])) {
+				end++
+			}
+
+			tokens = append(tokens, expressionToken{text: expression[i:end]})
+			i = end
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	return tokens, nil
+}
+
+type expressionParser struct {
+	tokens   []expressionToken
+	position int
+}
+
+// keyword tells whether the next token is the unquoted keyword, and consumes it when it is.
+func (p *expressionParser) keyword(keyword string) bool {
+	if p.position < len(p.tokens) && !p.tokens[p.position].quoted &&
+		strings.EqualFold(p.tokens[p.position].text, keyword) {
+		p.position++
+		return true
+	}
+
+	return false
+}
+
+func (p *expressionParser) next(expected string) (expressionToken, error) {
+	if p.position >= len(p.tokens) {
+		return expressionToken{}, errors.New("expected " + expected + " at the end")
+	}
+
+	p.position++
+
+	return p.tokens[p.position-1], nil
+}
+
+func (p *expressionParser) parseOr() (Expression, error) {
+	var operands orExpression
+
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, operand)
+		if !p.keyword("or") {
+			break
+		}
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return operands, nil
+}
+
+func (p *expressionParser) parseAnd() (Expression, error) {
+	var operands andExpression
+
+	for {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, operand)
+		if !p.keyword("and") {
+			break
+		}
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return operands, nil
+}
+
+func (p *expressionParser) parseNot() (Expression, error) {
+	if p.keyword("not") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpression{operand}, nil
+	}
+
+	if p.keyword("(") {
+		operand, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.keyword(")") {
+			return nil, errors.New("expected ) to close (")
+		}
+
+		return operand, nil
+	}
+
+	return p.parseCondition()
+}
+
+func (p *expressionParser) parseCondition() (Expression, error) {
+	token, err := p.next("a dimension")
+	if err != nil {
+		return nil, err
+	}
+
+	dimension := strings.ToLower(token.text)
+	if token.quoted || !slices.Contains(constants.Dimensions, dimension) {
+		return nil, errors.New("unknown dimension " + token.text + ", must be one of " +
+			strings.Join(constants.Dimensions, ", "))
+	}
+
+	condition := Condition{Dimension: dimension}
+
+	switch {
+	case p.keyword("not"):
+		if !p.keyword("in") {
+			return nil, errors.New("expected in after " + dimension + " not")
+		}
+
+		condition.Operator = OperatorNotIn
+	case p.keyword("in"):
+		condition.Operator = OperatorIn
+	default:
+		operator, err := p.next("an operator after " + dimension)
+		if err != nil {
+			return nil, err
+		}
+
+		if operator.quoted || !slices.Contains(append([]string{OperatorEqual, OperatorNotEqual}, comparisonOperators...),
+			operator.text) {
+			return nil, errors.New("unexpected " + operator.text + " after " + dimension +
+				", expected =, !=, <, <=, >, >=, in or not in")
+		}
+
+		condition.Operator = operator.text
+	}
+
+	if condition.Operator == OperatorIn || condition.Operator == OperatorNotIn {
+		condition.Values, err = p.parseList(condition.Operator)
+	} else {
+		var value string
+		value, err = p.parseValue(condition.Operator)
+		condition.Values = []string{value}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dimension == constants.Country {
+		condition.Values = CanonicalCountries(condition.Values)
+	}
+
+	if slices.Contains(comparisonOperators, condition.Operator) {
+		if !slices.Contains(constants.VersionDimensions, dimension) {
+			return nil, errors.New("only " + strings.Join(constants.VersionDimensions, " and ") + " compare with " +
+				condition.Operator)
+		}
+
+		if CanonicalVersion(condition.Values[0]) == "" {
+			return nil, errors.New("invalid version " + condition.Values[0])
+		}
+	}
+
+	return condition, nil
+}
+
+// parseList reads the parenthesized, comma separated values of in and not in.
+func (p *expressionParser) parseList(operator string) ([]string, error) {
+	if !p.keyword("(") {
+		return nil, errors.New("expected ( after " + operator)
+	}
+
+	var values []string
+	for {
+		value, err := p.parseValue(operator)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		if p.keyword(")") {
+			return values, nil
+		}
+
+		if !p.keyword(",") {
+			return nil, errors.New("expected , or ) in the values of " + operator)
+		}
+	}
+}
+
+// parseValue reads a value, keywords being values here so that country = in is India.
+func (p *expressionParser) parseValue(operator string) (string, error) {
+	token, err := p.next("a value after " + operator)
+	if err != nil {
+		return "", err
+	}
+
+	if !token.quoted && strings.ContainsAny(token.text, "(),=!<>") {
+		return "", errors.New("expected a value after " + operator + ", found " + token.text)
+	}
+
+	value := strings.TrimSpace(token.text)
+	if value == "" {
+		return "", errors.New("empty value after " + operator)
+	}
+
+	if !IsRegex(value) {
+		value = strings.ToLower(value)
+	}
+
+	return value, nil
+}
+
+func (c Condition) Evaluate(holds func(Condition) bool) bool {
+	return holds(c)
+}
+
+func (c Condition) Conditions() []Condition {
+	return []Condition{c}
+}
+
+func (c Condition) String() string {
+	values := make([]string, 0, len(c.Values))
+	for _, value := range c.Values {
+		values = append(values, quoteValue(value))
+	}
+
+	if c.Operator == OperatorIn || c.Operator == OperatorNotIn {
+		return c.Dimension + " " + c.Operator + " (" + strings.Join(values, ", ") + ")"
+	}
+
+	return c.Dimension + " " + c.Operator + " " + values[0]
+}
+
+// quoteValue quotes the values that wouldn't read back as a single value.
+func quoteValue(value string) string {
+	if !strings.ContainsAny(value, " \t(),=!<>'\"") {
+		return value
+	}
+
+	if strings.Contains(value, "'") {
+		return `"` + value + `"`
+	}
+
+	return "'" + value + "'"
+}
+
+type andExpression []Expression
+
+func (e andExpression) Evaluate(holds func(Condition) bool) bool {
+	for _, operand := range e {
+		if !operand.Evaluate(holds) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e andExpression) Conditions() []Condition {
+	return operandConditions(e)
+}
+
+func (e andExpression) String() string {
+	operands := make([]string, 0, len(e))
+	for _, operand := range e {
+		if _, ok := operand.(orExpression); ok {
+			operands = append(operands, "("+operand.String()+")")
+			continue
+		}
+
+		operands = append(operands, operand.String())
+	}
+
+	return strings.Join(operands, " and ")
+}
+
+type orExpression []Expression
+
+func (e orExpression) Evaluate(holds func(Condition) bool) bool {
+	for _, operand := range e {
+		if operand.Evaluate(holds) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e orExpression) Conditions() []Condition {
+	return operandConditions(e)
+}
+
+func (e orExpression) String() string {
+	operands := make([]string, 0, len(e))
+	for _, operand := range e {
+		if _, ok := operand.(andExpression); ok {
+			operands = append(operands, "("+operand.String()+")")
+			continue
+		}
+
+		operands = append(operands, operand.String())
+	}
+
+	return strings.Join(operands, " or ")
+}
+
+type notExpression struct {
+	operand Expression
+}
+
+func (e notExpression) Evaluate(holds func(Condition) bool) bool {
+	return !e.operand.Evaluate(holds)
+}
+
+func (e notExpression) Conditions() []Condition {
+	return e.operand.Conditions()
+}
+
+func (e notExpression) String() string {
+	switch e.operand.(type) {
+	case andExpression, orExpression:
+		return "not (" + e.operand.String() + ")"
+	default:
+		return "not " + e.operand.String()
+	}
+}
+
+func operandConditions(operands []Expression) []Condition {
+	var conditions []Condition
+	for _, operand := range operands {
+		conditions = append(conditions, operand.Conditions()...)
+	}
+
+	return conditions
+}
@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// ParseFrequencyCap reads a cap written like FormatFrequencyCap does, "3/24h" being 3 impressions per 24 hours, and
+// returns nil for an empty value. The cap is validated along with the campaign.
+func ParseFrequencyCap(value string) (*models.FrequencyCap, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	impressions, window, ok := strings.Cut(value, "/")
+	if !ok {
+		return nil, errors.New("invalid frequency cap " + value + ", expected impressions/window like 3/24h")
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(impressions))
+	if err != nil {
+		return nil, errors.New("invalid frequency cap " + value + ", expected impressions/window like 3/24h")
+	}
+
+	return &models.FrequencyCap{Impressions: count, Window: strings.TrimSpace(window)}, nil
+}
+
+// FormatFrequencyCap returns "" for nil.
+func FormatFrequencyCap(frequencyCap *models.FrequencyCap) string {
+	if frequencyCap == nil {
+		return ""
+	}
+
+	return strconv.Itoa(frequencyCap.Impressions) + "/" + frequencyCap.Window
+}
+
+// ParseImpressionGoal reads the total and daily goal columns, empty ones being no goal, and returns nil when both are
+// empty. The goal is validated along with the campaign.
+func ParseImpressionGoal(total, daily string) (*models.ImpressionGoal, error) {
+	if total == "" && daily == "" {
+		return nil, nil
+	}
+
+	var goal models.ImpressionGoal
+	for _, column := range []struct {
+		value string
+		goal  *int64
+	}{{total, &goal.Total}, {daily, &goal.Daily}} {
+		if column.value == "" {
+			continue
+		}
+
+		count, err := strconv.ParseInt(strings.TrimSpace(column.value), 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid impression goal " + column.value + ", expected a number of impressions")
+		}
+
+		*column.goal = count
+	}
+
+	return &goal, nil
+}
+
+// FormatImpressionGoal returns the total and daily goal columns, "" for the goals that aren't set.
+func FormatImpressionGoal(goal *models.ImpressionGoal) (string, string) {
+	if goal == nil {
+		return "", ""
+	}
+
+	format := func(count int64) string {
+		if count == 0 {
+			return ""
+		}
+
+		return strconv.FormatInt(count, 10)
+	}
+
+	return format(goal.Total), format(goal.Daily)
+}
@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// initializeGeoIP opens the MaxMind country or city database at GEOIP_DB_PATH, nil when it isn't set. A database that
+// can't be opened is logged, delivery requests then need the country param.
+func initializeGeoIP(logger *slog.Logger) *maxminddb.Reader {
+	path := os.Getenv("GEOIP_DB_PATH")
+	if path == "" {
+		logger.Warn("GEOIP_DB_PATH is not set, delivery requests need the country param")
+		return nil
+	}
+
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		logger.Error("Error while Opening GeoIP database, delivery requests need the country param", "Path", path,
+			"Error", err.Error())
+		return nil
+	}
+
+	logger.Info("GeoIP database loaded", "Path", path, "Type", reader.Metadata.DatabaseType,
+		"BuildEpoch", reader.Metadata.BuildEpoch)
+
+	return reader
+}
+
+// trustedProxies reads the comma separated IPs and CIDRs of TRUSTED_PROXIES, whose X-Forwarded-For header gives the
+// client IP. None are trusted when it's empty.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
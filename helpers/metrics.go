@@ -47,6 +47,40 @@ func NewMetrics() *models.Metrics {
 			},
 			[]string{"cache_name"},
 		),
+		ChangeEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "change_events_processed_total",
+				Help: "Total number of campaign and rule changes processed.",
+			},
+			[]string{"source", "operation"},
+		),
+		ChangeLag: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "change_event_lag_seconds",
+				Help:    "Histogram of delays between a change in MongoDB and its processing.",
+				Buckets: prometheus.DefBuckets,
+			},
+		),
+		CountrySources: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "delivery_country_sources_total",
+				Help: "Total number of delivery requests by source of their country.",
+			},
+			[]string{"source"},
+		),
+		CampaignEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "campaign_events_total",
+				Help: "Total number of events accepted by campaign and type.",
+			},
+			[]string{"campaign_id", "type"},
+		),
+		EventsDropped: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "events_dropped_total",
+				Help: "Total number of accepted events dropped without reaching the sink.",
+			},
+		),
 	}
 
 	metricsOnce.Do(func() {
@@ -55,6 +89,11 @@ func NewMetrics() *models.Metrics {
 		prometheus.MustRegister(m.ErrorCounter)
 		prometheus.MustRegister(m.CacheHits)
 		prometheus.MustRegister(m.CacheMisses)
+		prometheus.MustRegister(m.ChangeEvents)
+		prometheus.MustRegister(m.ChangeLag)
+		prometheus.MustRegister(m.CountrySources)
+		prometheus.MustRegister(m.CampaignEvents)
+		prometheus.MustRegister(m.EventsDropped)
 	})
 
 	return m
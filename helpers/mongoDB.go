@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -13,7 +14,8 @@ func InitializeMongo(logger *slog.Logger) *mongo.Database {
 	mongoURI := os.Getenv("MONGO_URI")
 	dbName := os.Getenv("MONGO_DB_NAME")
 
-	clientOptions := options.Client().ApplyURI(mongoURI)
+	// Requests fail fast with a 503 while MongoDB is down, instead of waiting for the default 30s.
+	clientOptions := options.Client().ApplyURI(mongoURI).SetServerSelectionTimeout(5 * time.Second)
 
 	client, err := mongo.Connect(context.TODO(), clientOptions)
 	if err != nil {
@@ -21,10 +23,15 @@ func InitializeMongo(logger *slog.Logger) *mongo.Database {
 		return nil
 	}
 
-	err = client.Ping(context.TODO(), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	err = client.Ping(ctx, nil)
 	if err != nil {
-		logger.Error("Failed to Ping MongoDB", "ERROR", err)
-		return nil
+		logger.Error("Failed to Ping MongoDB, reconnecting in the background", "ERROR", err)
+		reconnect(logger, "MongoDB", func(ctx context.Context) error { return client.Ping(ctx, nil) })
+
+		return client.Database(dbName)
 	}
 
 	logger.Info("Connected to MongoDB Successfully", "Credentials", map[string]string{
@@ -1,15 +1,98 @@
 package helpers
 
 import (
-	"github.com/Durga-Chikkala/delivery-service/models"
+	"context"
+	"database/sql"
 	"os"
+	"strconv"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func New() *models.Helpers {
 	LoadConfigs()
 	logger := InitializeLogger()
-	db := InitializeMongo(logger)
+	storeBackend := os.Getenv("STORE_BACKEND")
+	if storeBackend == "" {
+		storeBackend = constants.BackendMongo
+	}
+
+	var db *mongo.Database
+	var sqlDB *sql.DB
+	switch storeBackend {
+	case constants.BackendPostgres:
+		sqlDB = InitializePostgres(logger)
+	case constants.BackendFile:
+		// the files are loaded by the stores package
+	default:
+		db = InitializeMongo(logger)
+	}
+
+	fileStorePath := os.Getenv("FILE_STORE_PATH")
+	if fileStorePath == "" {
+		fileStorePath = "stores/testdata"
+	}
+
+	// Events go to MongoDB along with the campaigns, and to NDJSON files with the other backends.
+	eventSink := os.Getenv("EVENT_SINK")
+	if eventSink == "" {
+		eventSink = constants.EventSinkFile
+		if storeBackend == constants.BackendMongo {
+			eventSink = constants.EventSinkMongo
+		}
+	}
+
+	eventFilePath := os.Getenv("EVENT_FILE_PATH")
+	if eventFilePath == "" {
+		eventFilePath = "events"
+	}
+
+	eventBatchSize, err := strconv.Atoi(os.Getenv("EVENT_BATCH_SIZE"))
+	if err != nil || eventBatchSize <= 0 {
+		eventBatchSize = 500
+	}
+
+	eventBufferSize, err := strconv.Atoi(os.Getenv("EVENT_BUFFER_SIZE"))
+	if err != nil || eventBufferSize < eventBatchSize {
+		eventBufferSize = 20 * eventBatchSize
+	}
+
+	eventFlushInterval, err := time.ParseDuration(os.Getenv("EVENT_FLUSH_INTERVAL"))
+	if err != nil || eventFlushInterval <= 0 {
+		eventFlushInterval = 5 * time.Second
+	}
+
+	beaconKeys, err := beaconKeys()
+	if err != nil {
+		logger.Error("Error while Reading BEACON_KEYS, the responses have no beacon URLs", "Error", err.Error())
+	} else if len(beaconKeys) == 0 {
+		logger.Warn("BEACON_KEYS is not set, the responses have no beacon URLs")
+	}
+
+	// Events without a beacon token can't be told from forged ones, they are only accepted until beacons are enabled.
+	unsignedEvents, err := strconv.ParseBool(os.Getenv("EVENTS_ALLOW_UNSIGNED"))
+	if err != nil {
+		unsignedEvents = true
+	}
+
+	if unsignedEvents && len(beaconKeys) > 0 {
+		if os.Getenv("EVENTS_ALLOW_UNSIGNED") != "" {
+			logger.Warn("EVENTS_ALLOW_UNSIGNED is ignored, events need a beacon token once BEACON_KEYS is set")
+		}
+
+		unsignedEvents = false
+	}
+
+	beaconTTL, err := time.ParseDuration(os.Getenv("BEACON_TTL"))
+	if err != nil || beaconTTL <= 0 {
+		beaconTTL = 24 * time.Hour
+	}
+
 	redisDB := initializeRedis(logger)
+	geoIP := initializeGeoIP(logger)
 	metrics := NewMetrics()
 
 	appName := os.Getenv("APP_NAME")
@@ -22,5 +105,60 @@ func New() *models.Helpers {
 		port = "8000"
 	}
 
-	return &models.Helpers{AppName: appName, AppPort: port, DB: db, Redis: redisDB, Metrics: metrics, Logger: logger}
+	indexRefreshInterval, err := time.ParseDuration(os.Getenv("INDEX_REFRESH_INTERVAL"))
+	if err != nil || indexRefreshInterval <= 0 {
+		indexRefreshInterval = time.Minute
+	}
+
+	readinessTimeout, err := time.ParseDuration(os.Getenv("READINESS_TIMEOUT"))
+	if err != nil || readinessTimeout <= 0 {
+		readinessTimeout = 2 * time.Second
+	}
+
+	shutdownTimeout, err := time.ParseDuration(os.Getenv("SHUTDOWN_TIMEOUT"))
+	if err != nil || shutdownTimeout <= 0 {
+		shutdownTimeout = 15 * time.Second
+	}
+
+	// A delay of 0 is valid, the listener is then closed right away.
+	shutdownDelay, err := time.ParseDuration(os.Getenv("SHUTDOWN_DELAY"))
+	if err != nil || shutdownDelay < 0 {
+		shutdownDelay = 0
+	}
+
+	return &models.Helpers{AppName: appName, AppPort: port, IndexRefreshInterval: indexRefreshInterval,
+		ReadinessTimeout: readinessTimeout, ShutdownTimeout: shutdownTimeout, ShutdownDelay: shutdownDelay, StoreBackend: storeBackend, FileStorePath: fileStorePath,
+		EventSink: eventSink, EventFilePath: eventFilePath, EventBatchSize: eventBatchSize, EventBufferSize: eventBufferSize,
+		EventFlushInterval: eventFlushInterval, BeaconKeys: beaconKeys, BeaconTTL: beaconTTL,
+		BeaconBaseURL: os.Getenv("BEACON_BASE_URL"), UnsignedEvents: unsignedEvents, DB: db, SQL: sqlDB,
+		Redis: redisDB, TrustedProxies: trustedProxies(), GeoIP: geoIP, Metrics: metrics, Logger: logger}
+}
+
+// Close disconnects from the store and Redis and closes the GeoIP database, once nothing uses them anymore.
+func Close(ctx context.Context, helper *models.Helpers) {
+	if helper.DB != nil {
+		if err := helper.DB.Client().Disconnect(ctx); err != nil {
+			helper.Logger.Error("Error while Disconnecting from MongoDB", "Error", err.Error())
+		}
+	}
+
+	if helper.SQL != nil {
+		if err := helper.SQL.Close(); err != nil {
+			helper.Logger.Error("Error while Closing PostgreSQL", "Error", err.Error())
+		}
+	}
+
+	if helper.Redis != nil {
+		if err := helper.Redis.Close(); err != nil {
+			helper.Logger.Error("Error while Closing Redis", "Error", err.Error())
+		}
+	}
+
+	if helper.GeoIP != nil {
+		if err := helper.GeoIP.Close(); err != nil {
+			helper.Logger.Error("Error while Closing GeoIP database", "Error", err.Error())
+		}
+	}
+
+	helper.Logger.Info("Connections closed")
 }
@@ -0,0 +1,77 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PatternSyntax matches the rule values that are patterns rather than exact values, in the regular expression syntax
+// shared by Go, MongoDB and PostgreSQL.
+const PatternSyntax = `[*?]|^/.*/$`
+
+var patternSyntax = regexp.MustCompile(PatternSyntax)
+
+// IsPattern tells whether the rule value is a glob like com.google.* or a regular expression between slashes like
+// /com\.(google|android)\..+/.
+func IsPattern(value string) bool {
+	return patternSyntax.MatchString(value)
+}
+
+// IsRegex tells whether the rule value is a regular expression between slashes.
+func IsRegex(value string) bool {
+	return len(value) > 1 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/")
+}
+
+// CompilePattern compiles the pattern to a regular expression matching whole values, case insensitively as values
+// are lower cased. In a glob, * stands for any characters and ? for a single one. Nothing is cached here, callers
+// matching a pattern repeatedly keep what it compiles to.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	var expression string
+	if IsRegex(pattern) {
+		expression = pattern[1 : len(pattern)-1]
+	} else {
+		var glob strings.Builder
+		for _, char := range pattern {
+			switch char {
+			case '*':
+				glob.WriteString(".*")
+			case '?':
+				glob.WriteString(".")
+			default:
+				glob.WriteString(regexp.QuoteMeta(string(char)))
+			}
+		}
+
+		expression = glob.String()
+	}
+
+	return regexp.Compile("(?i)^(?:" + expression + ")$")
+}
+
+// MatchPattern tells whether the value matches the pattern, compiling it, an invalid pattern never matches.
+func MatchPattern(pattern, value string) bool {
+	compiled, err := CompilePattern(pattern)
+
+	return err == nil && compiled.MatchString(value)
+}
+
+// SplitValues splits the pipe separated values of a rules file, keeping the pipes of regular expressions like
+// /(google|android)/ in them.
+func SplitValues(values string) []string {
+	split := make([]string, 0)
+	if values == "" {
+		return split
+	}
+
+	for _, value := range strings.Split(values, "|") {
+		last := len(split) - 1
+		if last >= 0 && strings.HasPrefix(split[last], "/") && !IsRegex(split[last]) {
+			split[last] += "|" + value
+			continue
+		}
+
+		split = append(split, value)
+	}
+
+	return split
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+
+	_ "github.com/lib/pq"
+)
+
+func InitializePostgres(logger *slog.Logger) *sql.DB {
+	db, err := sql.Open("postgres", os.Getenv("POSTGRES_DSN"))
+	if err != nil {
+		logger.Error("Failed to Connect to PostgreSQL", "ERROR", err)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		logger.Error("Failed to Ping PostgreSQL, reconnecting in the background", "ERROR", err)
+		reconnect(logger, "PostgreSQL", db.PingContext)
+
+		return db
+	}
+
+	logger.Info("Connected to PostgreSQL Successfully")
+
+	return db
+}
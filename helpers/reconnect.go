@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	pingTimeout       = 2 * time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// reconnect pings the dependency in the background, backing off up to maxReconnectDelay, until it answers. The
+// clients reconnect on their own, this tells when the dependency is back.
+func reconnect(logger *slog.Logger, name string, ping func(ctx context.Context) error) {
+	go func() {
+		delay := time.Second
+
+		for {
+			time.Sleep(delay)
+
+			ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+			err := ping(ctx)
+			cancel()
+
+			if err == nil {
+				logger.Info("Reconnected to " + name)
+				return
+			}
+
+			logger.Warn(name+" is still unavailable", "Error", err.Error(), "RetryIn", delay.String())
+			delay = min(2*delay, maxReconnectDelay)
+		}
+	}()
+}
@@ -5,26 +5,39 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
+// initializeRedis returns nil when no REDIS_ADDR is set, which disables the cache. An unreachable Redis is retried in
+// the background, the cache errors meanwhile are ignored and delivery is served from the store.
 func initializeRedis(logger *slog.Logger) *redis.Client {
 	addr := os.Getenv("REDIS_ADDR")
 	password := os.Getenv("REDIS_PASSWORD")
 	db := os.Getenv("REDIS_DB")
 	dbNumber, _ := strconv.Atoi(db)
 
+	if addr == "" {
+		logger.Warn("REDIS_ADDR is not set, the cache is disabled")
+		return nil
+	}
+
 	rdb := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: password,
-		DB:       dbNumber,
+		Addr:        addr,
+		Password:    password,
+		DB:          dbNumber,
+		DialTimeout: time.Second,
 	})
 
-	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
 	if err := rdb.Ping(ctx).Err(); err != nil {
-		logger.Error("Could not connect to Redis", "Error", err)
-		return nil
+		logger.Error("Could not connect to Redis, reconnecting in the background", "Error", err)
+		reconnect(logger, "Redis", func(ctx context.Context) error { return rdb.Ping(ctx).Err() })
+
+		return rdb
 	}
 
 	logger.Info("Connected to Redis!")
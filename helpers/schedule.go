@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"errors"
+	"time"
+)
+
+// scheduleLayouts are the layouts accepted for the start and end of a campaign in files, the ones without an offset
+// are in the campaign's timezone.
+var scheduleLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// ParseScheduleTime returns nil for an empty value. The timezone defaults to UTC.
+func ParseScheduleTime(value, timezone string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, layout := range scheduleLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, errors.New("invalid time " + value + ", expected RFC3339 or 2006-01-02 15:04:05")
+}
+
+// FormatScheduleTime formats the time in the timezone, or UTC when it isn't valid, and returns "" for nil.
+func FormatScheduleTime(t *time.Time, timezone string) string {
+	if t == nil {
+		return ""
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	return t.In(location).Format(time.RFC3339)
+}
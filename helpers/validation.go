@@ -0,0 +1,288 @@
+package helpers
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// NormalizeCampaign lower cases the campaign id, the same way delivery dimensions are lower cased, so that
+// ids stay consistent with the rules referring to them.
+func NormalizeCampaign(campaign *models.Campaign) {
+	campaign.CampaignID = strings.ToLower(strings.TrimSpace(campaign.CampaignID))
+	campaign.Name = strings.TrimSpace(campaign.Name)
+	campaign.Image = strings.TrimSpace(campaign.Image)
+	campaign.CTA = strings.TrimSpace(campaign.CTA)
+	campaign.Status = strings.ToUpper(strings.TrimSpace(campaign.Status))
+	campaign.Timezone = strings.TrimSpace(campaign.Timezone)
+
+	if campaign.FrequencyCap != nil {
+		campaign.FrequencyCap.Window = strings.ToLower(strings.TrimSpace(campaign.FrequencyCap.Window))
+	}
+}
+
+// ValidateCampaign checks a normalized campaign, whether it comes from the API, an import or the files of the file
+// backend.
+func ValidateCampaign(campaign *models.Campaign) error {
+	if campaign.CampaignID == "" {
+		return invalidParam("Parameter campaign_id is required")
+	}
+
+	if strings.ContainsAny(campaign.CampaignID, ": \t") {
+		return invalidParam("Parameter campaign_id must not contain spaces or ':'")
+	}
+
+	if campaign.Name == "" {
+		return invalidParam("Parameter name is required")
+	}
+
+	if campaign.Image == "" {
+		return invalidParam("Parameter image is required")
+	}
+
+	imageURL, err := url.ParseRequestURI(campaign.Image)
+	if err != nil || (imageURL.Scheme != "http" && imageURL.Scheme != "https") || imageURL.Host == "" {
+		return invalidParam("Parameter image must be an absolute http(s) URL")
+	}
+
+	if campaign.CTA == "" {
+		return invalidParam("Parameter cta is required")
+	}
+
+	if campaign.Status != constants.StatusActive && campaign.Status != constants.StatusInactive {
+		return invalidParam("Parameter status must be one of ACTIVE, INACTIVE")
+	}
+
+	if _, err := time.LoadLocation(campaign.Timezone); err != nil {
+		return invalidParam("Parameter timezone must be an IANA time zone, like Asia/Kolkata")
+	}
+
+	if campaign.StartAt != nil && campaign.EndAt != nil && !campaign.EndAt.After(*campaign.StartAt) {
+		return invalidParam("Parameter end_at must be after start_at")
+	}
+
+	if campaign.Priority < 0 {
+		return invalidParam("Parameter priority must not be negative")
+	}
+
+	if campaign.Weight < 0 {
+		return invalidParam("Parameter weight must not be negative")
+	}
+
+	if campaign.FrequencyCap != nil {
+		window, err := time.ParseDuration(campaign.FrequencyCap.Window)
+		if campaign.FrequencyCap.Impressions < 1 || err != nil || window <= 0 {
+			return invalidParam("Parameter frequency_cap must have at least 1 impression and a window like 24h")
+		}
+	}
+
+	if goal := campaign.ImpressionGoal; goal != nil {
+		if goal.Total < 0 || goal.Daily < 0 || goal.Total == 0 && goal.Daily == 0 {
+			return invalidParam("Parameter impression_goal must have a positive total or daily goal")
+		}
+	}
+
+	return nil
+}
+
+// NormalizeRules lower cases the dimensions and values of the rules and writes countries canonical.
+func NormalizeRules(rules []models.Rule) {
+	for i := range rules {
+		rules[i].Dimension = strings.ToLower(strings.TrimSpace(rules[i].Dimension))
+		rules[i].Include = normalizeValues(rules[i].Include)
+		rules[i].Exclude = normalizeValues(rules[i].Exclude)
+
+		if rules[i].Dimension == constants.Country {
+			rules[i].Include = CanonicalCountries(rules[i].Include)
+			rules[i].Exclude = CanonicalCountries(rules[i].Exclude)
+		}
+
+		for j := range rules[i].Windows {
+			rules[i].Windows[j].Days = normalizeValues(rules[i].Windows[j].Days)
+		}
+	}
+}
+
+// normalizeValues lower cases the values and drops blanks and duplicates. It never returns nil, as
+// rules are stored with empty lists rather than missing ones. Regular expressions are kept as they are, lower casing
+// would change classes like \S, they match case insensitively anyway.
+func normalizeValues(values []string) []string {
+	normalized := make([]string, 0, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if !IsRegex(value) {
+			value = strings.ToLower(value)
+		}
+
+		if value == "" || slices.Contains(normalized, value) {
+			continue
+		}
+
+		normalized = append(normalized, value)
+	}
+
+	return normalized
+}
+
+// ValidateRules checks the normalized rules of a campaign.
+func ValidateRules(rules []models.Rule) error {
+	seen := make(map[string]bool)
+
+	for _, rule := range rules {
+		if !slices.Contains(constants.RuleDimensions, rule.Dimension) {
+			return invalidParam("Unknown dimension '" + rule.Dimension + "', must be one of " +
+				strings.Join(constants.RuleDimensions, ", "))
+		}
+
+		if seen[rule.Dimension] {
+			return invalidParam("Dimension '" + rule.Dimension + "' is defined more than once")
+		}
+
+		seen[rule.Dimension] = true
+
+		if err := validateWindows(rule); err != nil {
+			return err
+		}
+
+		if err := validateDevices(rule); err != nil {
+			return err
+		}
+
+		if err := validateVersions(rule); err != nil {
+			return err
+		}
+
+		if err := validatePatterns(rule); err != nil {
+			return err
+		}
+
+		for _, value := range rule.Include {
+			if slices.Contains(rule.Exclude, value) {
+				return invalidParam("Value '" + value + "' of dimension '" + rule.Dimension +
+					"' is both included and excluded")
+			}
+		}
+	}
+
+	return nil
+}
+
+// NormalizeExpression parses the expression and returns it in its canonical form, the values of its conditions being
+// validated like the values of the rules.
+func NormalizeExpression(expression string) (string, error) {
+	if strings.TrimSpace(expression) == "" {
+		return "", nil
+	}
+
+	parsed, err := ParseExpression(expression)
+	if err != nil {
+		return "", invalidParam("Parameter expression is invalid: " + err.Error())
+	}
+
+	for _, condition := range parsed.Conditions() {
+		rule := models.Rule{Dimension: condition.Dimension, Include: condition.Values}
+
+		if err := validateDevices(rule); err != nil {
+			return "", err
+		}
+
+		if err := validateVersions(rule); err != nil {
+			return "", err
+		}
+
+		if err := validatePatterns(rule); err != nil {
+			return "", err
+		}
+	}
+
+	return parsed.String(), nil
+}
+
+func validateDevices(rule models.Rule) error {
+	if rule.Dimension != constants.Device {
+		return nil
+	}
+
+	for _, value := range append(slices.Clone(rule.Include), rule.Exclude...) {
+		if !slices.Contains(constants.Devices, value) {
+			return invalidParam("Value '" + value + "' of dimension '" + constants.Device + "' must be one of " +
+				strings.Join(constants.Devices, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateVersions checks the ranges of the version dimensions, values without an operator match as they are.
+func validateVersions(rule models.Rule) error {
+	if !slices.Contains(constants.VersionDimensions, rule.Dimension) {
+		return nil
+	}
+
+	for _, value := range append(slices.Clone(rule.Include), rule.Exclude...) {
+		if !IsVersionRange(value) {
+			continue
+		}
+
+		if _, err := ParseVersionRange(value); err != nil {
+			return invalidParam("Value '" + value + "' of dimension '" + rule.Dimension +
+				"' must be a version or a range like >=5.2.0, <6 or ~1.4: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+func validatePatterns(rule models.Rule) error {
+	for _, value := range append(slices.Clone(rule.Include), rule.Exclude...) {
+		if !IsPattern(value) {
+			continue
+		}
+
+		if _, err := CompilePattern(value); err != nil {
+			return invalidParam("Value '" + value + "' of dimension '" + rule.Dimension + "' is an invalid pattern: " +
+				err.Error())
+		}
+	}
+
+	return nil
+}
+
+// validateWindows checks that only daypart rules have windows, and that they have nothing else.
+func validateWindows(rule models.Rule) error {
+	if rule.Dimension != constants.Daypart {
+		if len(rule.Windows) > 0 {
+			return invalidParam("Only dimension '" + constants.Daypart + "' takes windows")
+		}
+
+		return nil
+	}
+
+	if len(rule.Include) > 0 || len(rule.Exclude) > 0 {
+		return invalidParam("Dimension '" + constants.Daypart + "' takes windows, not include or exclude values")
+	}
+
+	for _, window := range rule.Windows {
+		for _, day := range window.Days {
+			if !slices.Contains(constants.Weekdays, day) {
+				return invalidParam("Unknown day '" + day + "', must be one of " + strings.Join(constants.Weekdays, ", "))
+			}
+		}
+
+		if window.Start < 0 || window.Start > 23 || window.End < 1 || window.End > 24 || window.Start == window.End {
+			return invalidParam("Window start must be an hour from 0 to 23 and end a different hour from 1 to 24")
+		}
+	}
+
+	return nil
+}
+
+func invalidParam(reason string) error {
+	return &Error{StatusCode: http.StatusBadRequest, Code: "Invalid Param", Reason: reason}
+}
@@ -0,0 +1,169 @@
+package helpers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// VersionRange is a list of bounds a version must all satisfy, like ">=12 <14". A bare version is an exact bound,
+// compared with semver semantics so that "5.2" is "5.2.0".
+type VersionRange []versionBound
+
+type versionBound struct {
+	operator string
+	version  string
+}
+
+// versionOperators are checked in order, so that the two characters ones come before their prefixes.
+var versionOperators = []string{">=", "<=", ">", "<", "=", "~", "^"}
+
+// IsVersionRange tells whether the value of a version dimension is written with an operator.
+func IsVersionRange(value string) bool {
+	return strings.ContainsAny(value, "<>=~^")
+}
+
+// ParseVersionRange reads space separated bounds: >=5.2.0, <6, =1.2.3 or 1.2.3, ~1.4 (>=1.4.0 <1.5.0) and ^1.4
+// (>=1.4.0 <2.0.0).
+func ParseVersionRange(value string) (VersionRange, error) {
+	var bounds VersionRange
+
+	fields := strings.Fields(value)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		operator := ""
+		for _, candidate := range versionOperators {
+			if strings.HasPrefix(field, candidate) {
+				operator = candidate
+				break
+			}
+		}
+
+		// The operator may be separated from its version, like ">= 5.2".
+		if operator == field && i+1 < len(fields) {
+			i++
+			field += fields[i]
+		}
+
+		parsed, err := parseBound(operator, strings.TrimPrefix(field, operator))
+		if err != nil {
+			return nil, err
+		}
+
+		bounds = append(bounds, parsed...)
+	}
+
+	if len(bounds) == 0 {
+		return nil, errors.New("empty version range")
+	}
+
+	return bounds, nil
+}
+
+func parseBound(operator, version string) ([]versionBound, error) {
+	canonical := CanonicalVersion(version)
+	if canonical == "" {
+		return nil, errors.New("invalid version " + version)
+	}
+
+	switch operator {
+	case "", "=":
+		return []versionBound{{operator: "=", version: canonical}}, nil
+	case "~", "^":
+		upper, err := upperVersion(operator, version)
+		if err != nil {
+			return nil, err
+		}
+
+		return []versionBound{{operator: ">=", version: canonical}, {operator: "<", version: upper}}, nil
+	default:
+		return []versionBound{{operator: operator, version: canonical}}, nil
+	}
+}
+
+// upperVersion returns the excluded upper bound of ~version, which allows changes after the minor version when it's
+// given, and of ^version, which allows changes after the first non zero number.
+func upperVersion(operator, version string) (string, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	parts := strings.Split(core, ".")
+
+	numbers := make([]int, 0, len(parts))
+	for _, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return "", errors.New("invalid version " + version)
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	position := 0
+	switch {
+	case operator == "~" && len(numbers) > 1:
+		position = 1
+	case operator == "^":
+		for position < len(numbers)-1 && numbers[position] == 0 {
+			position++
+		}
+	}
+
+	upper := make([]string, 3)
+	for i := range upper {
+		switch {
+		case i < position:
+			upper[i] = strconv.Itoa(numbers[i])
+		case i == position:
+			upper[i] = strconv.Itoa(numbers[i] + 1)
+		default:
+			upper[i] = "0"
+		}
+	}
+
+	return "v" + strings.Join(upper, "."), nil
+}
+
+// Matches tells whether the version satisfies every bound, a value that isn't a version never does.
+func (r VersionRange) Matches(version string) bool {
+	canonical := CanonicalVersion(version)
+	if canonical == "" {
+		return false
+	}
+
+	for _, bound := range r {
+		compared := semver.Compare(canonical, bound.version)
+
+		var ok bool
+		switch bound.operator {
+		case "=":
+			ok = compared == 0
+		case ">=":
+			ok = compared >= 0
+		case "<=":
+			ok = compared <= 0
+		case ">":
+			ok = compared > 0
+		case "<":
+			ok = compared < 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CanonicalVersion returns the version as a full semantic version like v5.2.0, "" when it isn't one. The v prefix
+// and the minor and patch numbers are optional.
+func CanonicalVersion(version string) string {
+	version = "v" + strings.TrimPrefix(version, "v")
+	if !semver.IsValid(version) {
+		return ""
+	}
+
+	return semver.Canonical(version)
+}
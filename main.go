@@ -1,6 +1,13 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/Durga-Chikkala/delivery-service/handlers"
@@ -12,26 +19,129 @@ import (
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
 	router := gin.Default()
 	helper := helpers.New()
 
+	if err := router.SetTrustedProxies(helper.TrustedProxies); err != nil {
+		helper.Logger.Error("Error while Setting trusted proxies", "Error", err.Error())
+		return
+	}
+
 	// middlewares
 	middlewareMetrics := middlewares.Metrics{RequestCount: helper.Metrics.RequestCounter, RequestDuration: helper.Metrics.RequestDuration}
 	router.Use(middlewares.CORS(), middlewareMetrics.MetricsMiddleware())
 
 	// Injections
-	store := stores.New(helper.DB, helper.Redis, helper.Logger, helper.Metrics.CacheHits, helper.Metrics.CacheMisses)
-	svc := services.New(&store)
+	repo, err := stores.NewRepository(ctx, helper)
+	if err != nil {
+		helper.Logger.Error("Error while Initializing the store", "Backend", helper.StoreBackend, "Error", err.Error())
+		return
+	}
+
+	store := stores.New(repo, helper.Redis, helper.Logger, helper.Metrics.CacheHits, helper.Metrics.CacheMisses)
+	if _, err := store.RefreshIndex(ctx); err != nil {
+		helper.Logger.Error("Error while Loading targeting index, serving from the store", "Backend", helper.StoreBackend,
+			"Error", err.Error())
+	}
+
+	watcher := stores.NewWatcher(&store, helper.Metrics.ChangeEvents, helper.Metrics.ChangeLag, helper.IndexRefreshInterval)
+	watcherDone := make(chan struct{})
+	go func() {
+		watcher.Run(ctx)
+		close(watcherDone)
+	}()
+
+	eventSink, err := stores.NewEventSink(helper)
+	if err != nil {
+		helper.Logger.Error("Error while Initializing the event sink", "Sink", helper.EventSink, "Error", err.Error())
+		return
+	}
+
+	eventBuffer := stores.NewEventBuffer(eventSink, helper.Logger, helper.Metrics.CampaignEvents, helper.Metrics.EventsDropped,
+		helper.EventBatchSize, helper.EventBufferSize, helper.EventFlushInterval)
+	// The buffer outlives the requests being drained on shutdown, so it has a context of its own.
+	eventCtx, stopEvents := context.WithCancel(context.Background())
+	defer stopEvents()
+
+	eventBufferDone := make(chan struct{})
+	go func() {
+		eventBuffer.Run(eventCtx)
+		close(eventBufferDone)
+	}()
+
+	geoIP := stores.NewGeoIP(helper.GeoIP, helper.Logger, helper.Metrics.CountrySources)
+	beacons := helpers.NewBeaconSigner(helper.BeaconKeys, helper.BeaconTTL, helper.BeaconBaseURL)
+	svc := services.New(&store, geoIP, beacons)
 	handler := handlers.New(svc, helper.Metrics.ErrorCounter)
+	campaignSvc := services.NewCampaign(&store)
+	campaignHandler := handlers.NewCampaignHandler(campaignSvc, helper.Metrics.ErrorCounter)
+	ruleSvc := services.NewRule(&store, &store)
+	ruleHandler := handlers.NewRuleHandler(ruleSvc, helper.Metrics.ErrorCounter)
+	importSvc := services.NewImport(&store, &store, &store)
+	importHandler := handlers.NewImportHandler(importSvc, helper.Metrics.ErrorCounter)
+	exportSvc := services.NewExport(&store, &store)
+	exportHandler := handlers.NewExportHandler(exportSvc, helper.Metrics.ErrorCounter)
+	eventSvc := services.NewEvent(eventBuffer, &store, &store, beacons, helper.UnsignedEvents)
+	eventHandler := handlers.NewEventHandler(eventSvc, helper.Metrics.ErrorCounter)
+	pacingSvc := services.NewPacing(&store, &store)
+	pacingHandler := handlers.NewPacingHandler(pacingSvc, helper.Metrics.ErrorCounter)
+	healthSvc := services.NewHealth(&store, helper.ReadinessTimeout)
+	healthHandler := handlers.NewHealthHandler(healthSvc)
 
 	// Endpoints
 	router.GET("/v1/delivery", handler.Get)
+	router.GET("/v1/delivery/explain", handler.Explain)
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.POST("/v1/events", eventHandler.Create)
+	router.GET(helpers.BeaconPath, eventHandler.Beacon)
+	router.GET("/healthz", healthHandler.Live)
+	router.GET("/readyz", healthHandler.Ready)
 
-	err := router.Run(":" + helper.AppPort)
-	if err != nil {
-		helper.Logger.Error("Error While Running the Service", "Error", err.Error())
-		return
+	router.POST("/v1/campaigns", campaignHandler.Create)
+	router.GET("/v1/campaigns", campaignHandler.GetAll)
+	router.GET("/v1/campaigns/:id", campaignHandler.GetByID)
+	router.PUT("/v1/campaigns/:id", campaignHandler.Update)
+	router.PATCH("/v1/campaigns/:id", campaignHandler.Patch)
+	router.DELETE("/v1/campaigns/:id", campaignHandler.Delete)
+
+	router.GET("/v1/campaigns/:id/rules", ruleHandler.Get)
+	router.PUT("/v1/campaigns/:id/rules", ruleHandler.Save)
+	router.DELETE("/v1/campaigns/:id/rules", ruleHandler.Delete)
+
+	router.POST("/v1/admin/import", importHandler.Create)
+	router.GET("/v1/admin/export", exportHandler.Get)
+	router.GET("/v1/admin/pacing", pacingHandler.Get)
+
+	server := &http.Server{Addr: ":" + helper.AppPort, Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			helper.Logger.Error("Error While Running the Service", "Error", err.Error())
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	helper.Logger.Info("Shutting down", "Delay", helper.ShutdownDelay.String(), "Timeout", helper.ShutdownTimeout.String())
+
+	// Load balancers get the time to see the service unready before the listener is closed.
+	healthSvc.ShutDown()
+	time.Sleep(helper.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), helper.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		helper.Logger.Error("Error while Draining requests", "Error", err.Error())
 	}
 
+	<-watcherDone
+
+	// The buffered events are flushed once no request adds any anymore, before MongoDB is disconnected.
+	stopEvents()
+	<-eventBufferDone
+
+	helpers.Close(shutdownCtx, helper)
 }
@@ -1,31 +1,139 @@
 package models
 
 import (
+	"database/sql"
+	"encoding/json"
 	"github.com/go-redis/redis/v8"
+	"github.com/oschwald/maxminddb-golang"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.mongodb.org/mongo-driver/mongo"
 	"log/slog"
+	"time"
 )
 
 type Dimension struct {
 	APPID   string
 	Country string
 	OS      string
+	// The optional dimensions are empty when the request doesn't have them.
+	AppVersion string
+	OSVersion  string
+	Device     string
+	Lang       string
+	// TZ is the IANA time zone of the user, the country's one when empty.
+	TZ string
+	// LocalTime is the time of the request in the user's time zone, daypart rules are evaluated against it.
+	LocalTime time.Time
+	// ClientIP resolves the country when the request doesn't have it.
+	ClientIP string
+	// UserID identifies the user or the device frequency caps count the impressions of, none are counted without it.
+	UserID string
 }
 
 type Response struct {
 	CampaignID string `bson:"campaign_id" json:"cid"`
 	Image      string `bson:"image" json:"img"`
 	CTA        string `bson:"cta" json:"cta"`
+	// Priority and Weight rank the response, FrequencyCap and ImpressionGoal filter it in the Timezone of the
+	// campaign, they aren't part of it.
+	Priority       int             `bson:"priority" json:"-"`
+	Weight         int             `bson:"weight" json:"-"`
+	FrequencyCap   *FrequencyCap   `bson:"frequency_cap,omitempty" json:"-"`
+	ImpressionGoal *ImpressionGoal `bson:"impression_goal,omitempty" json:"-"`
+	Timezone       string          `bson:"timezone,omitempty" json:"-"`
+	// ImpressionURL, ClickURL and DismissURL are the signed beacons the client calls when the campaign is shown, tapped
+	// and dismissed.
+	ImpressionURL string `bson:"-" json:"impression_url,omitempty"`
+	ClickURL      string `bson:"-" json:"click_url,omitempty"`
+	DismissURL    string `bson:"-" json:"dismiss_url,omitempty"`
+}
+
+type Campaign struct {
+	CampaignID string `bson:"campaign_id" json:"campaign_id"`
+	Name       string `bson:"name" json:"name"`
+	Image      string `bson:"image" json:"image"`
+	CTA        string `bson:"cta" json:"cta"`
+	Status     string `bson:"status" json:"status"`
+	// StartAt and EndAt bound when an active campaign is delivered, either can be left open.
+	StartAt  *time.Time `bson:"start_at,omitempty" json:"start_at,omitempty"`
+	EndAt    *time.Time `bson:"end_at,omitempty" json:"end_at,omitempty"`
+	Timezone string     `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	// Priority ranks the delivered campaigns, higher first, and Weight breaks the ties between equal priorities.
+	Priority     int           `bson:"priority" json:"priority"`
+	Weight       int           `bson:"weight,omitempty" json:"weight,omitempty"`
+	FrequencyCap *FrequencyCap `bson:"frequency_cap,omitempty" json:"frequency_cap,omitempty"`
+	// ImpressionGoal stops the delivery once the campaign is delivered that many times.
+	ImpressionGoal *ImpressionGoal `bson:"impression_goal,omitempty" json:"impression_goal,omitempty"`
+}
+
+// FrequencyCap limits how many times a campaign is delivered to a user within a sliding window, like 3 impressions
+// per 24h.
+type FrequencyCap struct {
+	Impressions int    `bson:"impressions" json:"impressions"`
+	Window      string `bson:"window" json:"window"`
+}
+
+// ImpressionGoal limits the deliveries of a campaign in total and per day in its timezone, 0 leaving either unlimited.
+// A daily goal is paced evenly across the day.
+type ImpressionGoal struct {
+	Total int64 `bson:"total,omitempty" json:"total,omitempty"`
+	Daily int64 `bson:"daily,omitempty" json:"daily,omitempty"`
+}
+
+type CampaignPatch struct {
+	Name     *string      `json:"name"`
+	Image    *string      `json:"image"`
+	CTA      *string      `json:"cta"`
+	Status   *string      `json:"status"`
+	StartAt  OptionalTime `json:"start_at"`
+	EndAt    OptionalTime `json:"end_at"`
+	Timezone *string      `json:"timezone"`
+	Priority *int         `json:"priority"`
+	Weight   *int         `json:"weight"`
+	// FrequencyCap replaces the cap of the campaign, one without impressions removes it.
+	FrequencyCap *FrequencyCap `json:"frequency_cap"`
+	// ImpressionGoal replaces the goal of the campaign, one without total and daily goals removes it.
+	ImpressionGoal *ImpressionGoal `json:"impression_goal"`
+}
+
+// OptionalTime tells a field set to null, Set with a nil Time, from a field left out of the body. A patch sets the
+// bounds of the schedule given, null removing them.
+type OptionalTime struct {
+	Set  bool
+	Time *time.Time
+}
+
+func (t *OptionalTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+
+	return json.Unmarshal(data, &t.Time)
 }
 
 type Helpers struct {
-	AppName string
-	AppPort string
-	DB      *mongo.Database
-	Logger  *slog.Logger
-	Redis   *redis.Client
-	Metrics *Metrics
+	AppName              string
+	AppPort              string
+	IndexRefreshInterval time.Duration
+	ReadinessTimeout     time.Duration
+	ShutdownTimeout      time.Duration
+	ShutdownDelay        time.Duration
+	StoreBackend         string
+	FileStorePath        string
+	EventSink            string
+	EventFilePath        string
+	EventBatchSize       int
+	EventBufferSize      int
+	EventFlushInterval   time.Duration
+	BeaconKeys           []BeaconKey
+	BeaconTTL            time.Duration
+	BeaconBaseURL        string
+	UnsignedEvents       bool
+	TrustedProxies       []string
+	GeoIP                *maxminddb.Reader
+	DB                   *mongo.Database
+	SQL                  *sql.DB
+	Logger               *slog.Logger
+	Redis                *redis.Client
+	Metrics              *Metrics
 }
 
 type Metrics struct {
@@ -34,15 +142,148 @@ type Metrics struct {
 	ErrorCounter    *prometheus.CounterVec
 	CacheHits       *prometheus.CounterVec
 	CacheMisses     *prometheus.CounterVec
+	ChangeEvents    *prometheus.CounterVec
+	ChangeLag       prometheus.Histogram
+	CountrySources  *prometheus.CounterVec
+	CampaignEvents  *prometheus.CounterVec
+	EventsDropped   prometheus.Counter
 }
 
 type Rule struct {
-	Dimension string   `bson:"dimension" json:"dimension"`
-	Include   []string `bson:"include" json:"include"`
-	Exclude   []string `bson:"exclude" json:"exclude"`
+	Dimension string       `bson:"dimension" json:"dimension"`
+	Include   []string     `bson:"include" json:"include"`
+	Exclude   []string     `bson:"exclude" json:"exclude"`
+	Windows   []TimeWindow `bson:"windows,omitempty" json:"windows,omitempty"`
+}
+
+// TimeWindow covers the hours from Start until before End on the given days, every day when empty. A window whose
+// End is before its Start runs past midnight into the next day.
+type TimeWindow struct {
+	Days  []string `bson:"days" json:"days"`
+	Start int      `bson:"start" json:"start"`
+	End   int      `bson:"end" json:"end"`
 }
 
 type TargetingRule struct {
 	CampaignID string `bson:"campaign_id" json:"campaign_id"`
 	Rules      []Rule `bson:"rules" json:"rules"`
+	// Expression must hold too for the campaign to match, like (country = us and os = ios) or country = ca.
+	Expression string `bson:"expression,omitempty" json:"expression,omitempty"`
+}
+
+// CampaignImport is a campaign of an import along with its rules, either of them nil to leave it untouched.
+type CampaignImport struct {
+	Campaign *Campaign
+	Rule     *TargetingRule
+}
+
+type ImportReport struct {
+	DryRun  bool           `json:"dry_run"`
+	Errors  []ImportError  `json:"errors"`
+	Changes []ImportChange `json:"changes"`
+}
+
+type ImportError struct {
+	File       string `json:"file"`
+	Row        int    `json:"row"`
+	CampaignID string `json:"campaign_id,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+type ImportChange struct {
+	CampaignID string   `json:"campaign_id"`
+	Entity     string   `json:"entity"`
+	Action     string   `json:"action"`
+	Diff       []string `json:"diff,omitempty"`
+}
+
+type Explanation struct {
+	CampaignID string             `json:"campaign_id"`
+	Status     string             `json:"status"`
+	Delivered  bool               `json:"delivered"`
+	Reason     string             `json:"reason"`
+	Dimensions []DimensionVerdict `json:"dimensions"`
+}
+
+type DependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Required  bool    `json:"required"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Readiness struct {
+	Ready        bool               `json:"ready"`
+	Reasons      []string           `json:"reasons,omitempty"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+type DimensionVerdict struct {
+	Dimension string `json:"dimension"`
+	Value     string `json:"value"`
+	Verdict   string `json:"verdict"`
+	Matched   bool   `json:"matched"`
+}
+
+// Event reports what became of a delivered campaign on the device, at the Timestamp of the device.
+type Event struct {
+	Type       string          `bson:"type" json:"type"`
+	CampaignID string          `bson:"campaign_id" json:"campaign_id"`
+	Dimensions EventDimensions `bson:"dimensions" json:"dimensions"`
+	Timestamp  time.Time       `bson:"timestamp" json:"timestamp"`
+	ReceivedAt time.Time       `bson:"received_at" json:"received_at"`
+	// Token is the token of the beacon URL of the event, required once beacon URLs are enabled.
+	Token string `bson:"-" json:"token,omitempty"`
+}
+
+// EventDimensions are the dimensions of the delivery request the campaign was delivered to.
+type EventDimensions struct {
+	App        string `bson:"app,omitempty" json:"app,omitempty"`
+	Country    string `bson:"country,omitempty" json:"country,omitempty"`
+	OS         string `bson:"os,omitempty" json:"os,omitempty"`
+	AppVersion string `bson:"app_version,omitempty" json:"app_version,omitempty"`
+	OSVersion  string `bson:"os_version,omitempty" json:"os_version,omitempty"`
+	Device     string `bson:"device,omitempty" json:"device,omitempty"`
+	Lang       string `bson:"lang,omitempty" json:"lang,omitempty"`
+	UserID     string `bson:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+type EventBatch struct {
+	Events []Event `json:"events"`
+}
+
+// EventReceipt tells how many events are accepted, and how many are left out as their token was already used.
+type EventReceipt struct {
+	Accepted   int `json:"accepted"`
+	Duplicates int `json:"duplicates,omitempty"`
+}
+
+// BeaconKey signs the beacon tokens, ID naming it in the tokens so that older keys still verify during a rotation.
+type BeaconKey struct {
+	ID     string
+	Secret []byte
+}
+
+// BeaconClaims are what a beacon token vouches for, the event is recorded with them once the token is verified.
+type BeaconClaims struct {
+	Type       string          `json:"t"`
+	CampaignID string          `json:"c"`
+	Dimensions EventDimensions `json:"d"`
+	IssuedAt   int64           `json:"iat"`
+	Nonce      string          `json:"n"`
+}
+
+// Pacing is how far a campaign with an impression goal is delivered, counted in Redis.
+type Pacing struct {
+	CampaignID     string `json:"campaign_id"`
+	TotalGoal      int64  `json:"total_goal,omitempty"`
+	DailyGoal      int64  `json:"daily_goal,omitempty"`
+	DeliveredTotal int64  `json:"delivered_total"`
+	DeliveredToday int64  `json:"delivered_today"`
+	// ExpectedToday is where an even pace of the daily goal is at the time.
+	ExpectedToday int64 `json:"expected_today,omitempty"`
+	// Probability is the chance of the campaign being delivered to a matching request.
+	Probability float64 `json:"probability"`
+	State       string  `json:"state"`
 }
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+type CampaignService struct {
+	stores.Campaign
+}
+
+func NewCampaign(store stores.Campaign) CampaignService {
+	return CampaignService{Campaign: store}
+}
+
+func (s CampaignService) Create(ctx context.Context, campaign *models.Campaign) (*models.Campaign, error) {
+	helpers.NormalizeCampaign(campaign)
+
+	if err := helpers.ValidateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	if err := s.Campaign.CreateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func (s CampaignService) GetByID(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	return s.Campaign.GetCampaign(ctx, strings.ToLower(strings.TrimSpace(campaignID)))
+}
+
+func (s CampaignService) GetAll(ctx context.Context, status string) ([]models.Campaign, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status != "" && status != constants.StatusActive && status != constants.StatusInactive {
+		return nil, invalidParam("Parameter status must be one of ACTIVE, INACTIVE")
+	}
+
+	return s.Campaign.ListCampaigns(ctx, status)
+}
+
+func (s CampaignService) Update(ctx context.Context, campaignID string, campaign *models.Campaign) (*models.Campaign, error) {
+	campaign.CampaignID = campaignID
+	helpers.NormalizeCampaign(campaign)
+
+	if err := helpers.ValidateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	if err := s.Campaign.UpdateCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func (s CampaignService) Patch(ctx context.Context, campaignID string, patch *models.CampaignPatch) (*models.Campaign, error) {
+	campaign, err := s.GetByID(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Name != nil {
+		campaign.Name = *patch.Name
+	}
+
+	if patch.Image != nil {
+		campaign.Image = *patch.Image
+	}
+
+	if patch.CTA != nil {
+		campaign.CTA = *patch.CTA
+	}
+
+	if patch.Status != nil {
+		campaign.Status = *patch.Status
+	}
+
+	if patch.StartAt.Set {
+		campaign.StartAt = patch.StartAt.Time
+	}
+
+	if patch.EndAt.Set {
+		campaign.EndAt = patch.EndAt.Time
+	}
+
+	if patch.Timezone != nil {
+		campaign.Timezone = *patch.Timezone
+	}
+
+	if patch.Priority != nil {
+		campaign.Priority = *patch.Priority
+	}
+
+	if patch.Weight != nil {
+		campaign.Weight = *patch.Weight
+	}
+
+	if patch.FrequencyCap != nil {
+		campaign.FrequencyCap = patch.FrequencyCap
+		if patch.FrequencyCap.Impressions == 0 {
+			campaign.FrequencyCap = nil
+		}
+	}
+
+	if patch.ImpressionGoal != nil {
+		campaign.ImpressionGoal = patch.ImpressionGoal
+		if patch.ImpressionGoal.Total == 0 && patch.ImpressionGoal.Daily == 0 {
+			campaign.ImpressionGoal = nil
+		}
+	}
+
+	return s.Update(ctx, campaign.CampaignID, campaign)
+}
+
+func (s CampaignService) Delete(ctx context.Context, campaignID string) error {
+	return s.Campaign.DeleteCampaign(ctx, strings.ToLower(strings.TrimSpace(campaignID)))
+}
+
+func invalidParam(reason string) error {
+	return &helpers.Error{StatusCode: http.StatusBadRequest, Code: "Invalid Param", Reason: reason}
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func TestCampaignService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := stores.NewMockCampaign(ctrl)
+	ctx := context.Background()
+
+	service := NewCampaign(mockStore)
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		campaign       *models.Campaign
+		mockCalls      []interface{}
+		expectedResult *models.Campaign
+		expectedError  error
+	}{
+		{
+			name: "successful creation with normalization",
+			campaign: &models.Campaign{CampaignID: " Spotify ", Name: "Spotify Campaign",
+				Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "active"},
+			mockCalls: []interface{}{
+				mockStore.EXPECT().CreateCampaign(ctx, &models.Campaign{CampaignID: "spotify", Name: "Spotify Campaign",
+					Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "ACTIVE"}).Return(nil),
+			},
+			expectedResult: &models.Campaign{CampaignID: "spotify", Name: "Spotify Campaign",
+				Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "ACTIVE"},
+		},
+		{
+			name:          "missing campaign id",
+			campaign:      &models.Campaign{Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE"},
+			expectedError: invalidParam("Parameter campaign_id is required"),
+		},
+		{
+			name:          "campaign id with separator",
+			campaign:      &models.Campaign{CampaignID: "a:b", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE"},
+			expectedError: invalidParam("Parameter campaign_id must not contain spaces or ':'"),
+		},
+		{
+			name:          "missing name",
+			campaign:      &models.Campaign{CampaignID: "a", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE"},
+			expectedError: invalidParam("Parameter name is required"),
+		},
+		{
+			name:          "invalid image",
+			campaign:      &models.Campaign{CampaignID: "a", Name: "n", Image: "example.com/a.png", CTA: "c", Status: "ACTIVE"},
+			expectedError: invalidParam("Parameter image must be an absolute http(s) URL"),
+		},
+		{
+			name:          "missing cta",
+			campaign:      &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", Status: "ACTIVE"},
+			expectedError: invalidParam("Parameter cta is required"),
+		},
+		{
+			name:          "invalid status",
+			campaign:      &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "PAUSED"},
+			expectedError: invalidParam("Parameter status must be one of ACTIVE, INACTIVE"),
+		},
+		{
+			name: "unknown timezone",
+			campaign: &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE",
+				Timezone: "India"},
+			expectedError: invalidParam("Parameter timezone must be an IANA time zone, like Asia/Kolkata"),
+		},
+		{
+			name: "end before start",
+			campaign: &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE",
+				StartAt: &end, EndAt: &start},
+			expectedError: invalidParam("Parameter end_at must be after start_at"),
+		},
+		{
+			name: "negative priority",
+			campaign: &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE",
+				Priority: -1},
+			expectedError: invalidParam("Parameter priority must not be negative"),
+		},
+		{
+			name: "frequency cap without a window",
+			campaign: &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE",
+				FrequencyCap: &models.FrequencyCap{Impressions: 3}},
+			expectedError: invalidParam("Parameter frequency_cap must have at least 1 impression and a window like 24h"),
+		},
+		{
+			name: "impression goal without a positive goal",
+			campaign: &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE",
+				ImpressionGoal: &models.ImpressionGoal{Total: -100}},
+			expectedError: invalidParam("Parameter impression_goal must have a positive total or daily goal"),
+		},
+		{
+			name:     "store returns conflict",
+			campaign: &models.Campaign{CampaignID: "a", Name: "n", Image: "https://example.com/a.png", CTA: "c", Status: "ACTIVE"},
+			mockCalls: []interface{}{
+				mockStore.EXPECT().CreateCampaign(ctx, gomock.Any()).Return(&helpers.Error{StatusCode: http.StatusConflict}),
+			},
+			expectedError: &helpers.Error{StatusCode: http.StatusConflict},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Create(ctx, tt.campaign)
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestCampaignService_Patch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := stores.NewMockCampaign(ctrl)
+	ctx := context.Background()
+
+	service := NewCampaign(mockStore)
+
+	status, priority := "inactive", 5
+	existing := models.Campaign{CampaignID: "spotify", Name: "Spotify Campaign",
+		Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "ACTIVE", Weight: 2}
+	expected := existing
+	expected.Status = "INACTIVE"
+	expected.Priority = 5
+	expected.FrequencyCap = &models.FrequencyCap{Impressions: 3, Window: "24h"}
+	expected.ImpressionGoal = &models.ImpressionGoal{Daily: 5000}
+
+	gomock.InOrder(
+		mockStore.EXPECT().GetCampaign(ctx, "spotify").Return(&existing, nil),
+		mockStore.EXPECT().UpdateCampaign(ctx, &expected).Return(nil),
+	)
+
+	result, err := service.Patch(ctx, "Spotify", &models.CampaignPatch{Status: &status, Priority: &priority,
+		FrequencyCap: &models.FrequencyCap{Impressions: 3, Window: " 24H "}, ImpressionGoal: &models.ImpressionGoal{Daily: 5000}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &expected, result)
+
+	uncapped := expected
+	uncapped.FrequencyCap = nil
+	uncapped.ImpressionGoal = nil
+
+	gomock.InOrder(
+		mockStore.EXPECT().GetCampaign(ctx, "spotify").Return(&expected, nil),
+		mockStore.EXPECT().UpdateCampaign(ctx, &uncapped).Return(nil),
+	)
+
+	result, err = service.Patch(ctx, "spotify", &models.CampaignPatch{FrequencyCap: &models.FrequencyCap{},
+		ImpressionGoal: &models.ImpressionGoal{}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &uncapped, result)
+
+	// A null bound removes it, a missing one is kept.
+	startAt, endAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	scheduled := uncapped
+	scheduled.StartAt, scheduled.EndAt = &startAt, &endAt
+	unscheduled := scheduled
+	unscheduled.StartAt = nil
+
+	gomock.InOrder(
+		mockStore.EXPECT().GetCampaign(ctx, "spotify").Return(&scheduled, nil),
+		mockStore.EXPECT().UpdateCampaign(ctx, &unscheduled).Return(nil),
+	)
+
+	result, err = service.Patch(ctx, "spotify", &models.CampaignPatch{StartAt: models.OptionalTime{Set: true}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &unscheduled, result)
+
+	mockStore.EXPECT().GetCampaign(ctx, "unknown").Return(nil, &helpers.Error{StatusCode: http.StatusNotFound})
+
+	result, err = service.Patch(ctx, "unknown", &models.CampaignPatch{Status: &status})
+
+	assert.Nil(t, result)
+	assert.Equal(t, &helpers.Error{StatusCode: http.StatusNotFound}, err)
+}
+
+func TestCampaignService_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := stores.NewMockCampaign(ctrl)
+	ctx := context.Background()
+
+	service := NewCampaign(mockStore)
+
+	mockStore.EXPECT().ListCampaigns(ctx, "ACTIVE").Return([]models.Campaign{{CampaignID: "spotify"}}, nil)
+
+	result, err := service.GetAll(ctx, "active")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []models.Campaign{{CampaignID: "spotify"}}, result)
+
+	result, err = service.GetAll(ctx, "paused")
+
+	assert.Nil(t, result)
+	assert.Equal(t, invalidParam("Parameter status must be one of ACTIVE, INACTIVE"), err)
+}
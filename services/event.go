@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+// maxEventsPerRequest keeps a request within what the buffer takes at once.
+const maxEventsPerRequest = 100
+
+// eventClockSkew is how far in the future the clock of a device may put an event.
+const eventClockSkew = 5 * time.Minute
+
+type EventService struct {
+	stores.Events
+	campaign       stores.CampaignLookup
+	nonces         stores.Nonces
+	beacons        *helpers.BeaconSigner
+	unsignedEvents bool
+}
+
+// NewEvent takes a nil signer when beacon URLs are disabled, the beacons are then rejected. Events without a token are
+// only accepted with unsignedEvents, which is never set along with a signer.
+func NewEvent(events stores.Events, campaign stores.CampaignLookup, nonces stores.Nonces,
+	beacons *helpers.BeaconSigner, unsignedEvents bool) EventService {
+	return EventService{Events: events, campaign: campaign, nonces: nonces, beacons: beacons,
+		unsignedEvents: unsignedEvents}
+}
+
+// Record validates the events and buffers them, a single invalid event rejecting the whole batch so that the client
+// can resend it once fixed without duplicating the others. With beacon URLs enabled every event carries the token of
+// its beacon, which vouches for the campaign and the dimensions, and the events whose token was already used are
+// left out, so that a batch can be resent after a timeout.
+func (s EventService) Record(ctx context.Context, events []models.Event) (*models.EventReceipt, error) {
+	if s.beacons == nil && !s.unsignedEvents {
+		return nil, beaconError("Events need a beacon token, and beacon URLs are not enabled")
+	}
+
+	if len(events) == 0 || len(events) > maxEventsPerRequest {
+		return nil, invalidParam(fmt.Sprintf("Parameter events must have between 1 and %d events", maxEventsPerRequest))
+	}
+
+	now := time.Now().UTC()
+	known := make(map[string]bool)
+	claims := make([]*models.BeaconClaims, len(events))
+
+	for i := range events {
+		event := &events[i]
+
+		if s.beacons != nil {
+			var err error
+			if claims[i], err = s.signedEvent(event, i, now); err != nil {
+				return nil, err
+			}
+		}
+
+		normalizeEvent(event)
+
+		if err := validateEvent(event, i, now); err != nil {
+			return nil, err
+		}
+
+		if claims[i] == nil {
+			exists, ok := known[event.CampaignID]
+			if !ok {
+				var err error
+				if exists, err = s.campaign.CampaignExists(ctx, event.CampaignID); err != nil {
+					return nil, err
+				}
+
+				known[event.CampaignID] = exists
+			}
+
+			if !exists {
+				return nil, invalidParam(fmt.Sprintf("Parameter events[%d].campaign_id is not a known campaign", i))
+			}
+		}
+
+		event.ReceivedAt = now
+	}
+
+	// The nonces claimed are released when the events can't be buffered, so that the client can retry them.
+	accepted := events[:0:0]
+	var claimed []string
+	for i := range events {
+		if claims[i] != nil {
+			ok, err := s.nonces.ClaimNonce(ctx, claims[i].Nonce, s.beacons.ExpiresAt(claims[i]))
+			if err != nil {
+				s.releaseNonces(ctx, claimed)
+				return nil, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			claimed = append(claimed, claims[i].Nonce)
+		}
+
+		accepted = append(accepted, events[i])
+	}
+
+	if len(accepted) > 0 {
+		if err := s.Events.AddEvents(accepted); err != nil {
+			s.releaseNonces(ctx, claimed)
+			return nil, err
+		}
+	}
+
+	return &models.EventReceipt{Accepted: len(accepted), Duplicates: len(events) - len(accepted)}, nil
+}
+
+// signedEvent verifies the token of the event and sets the campaign and the dimensions it vouches for.
+func (s EventService) signedEvent(event *models.Event, i int, now time.Time) (*models.BeaconClaims, error) {
+	if event.Token == "" {
+		return nil, invalidParam(fmt.Sprintf("Parameter events[%d].token is required", i))
+	}
+
+	claims, reason := s.verifyBeacon(event.Token, now)
+	if claims == nil {
+		return nil, beaconError(fmt.Sprintf("Beacon token of events[%d] %s", i, reason))
+	}
+
+	if eventType := strings.ToLower(strings.TrimSpace(event.Type)); eventType != "" && eventType != claims.Type {
+		return nil, invalidParam(fmt.Sprintf("Parameter events[%d].type must be %s like its token", i, claims.Type))
+	}
+
+	event.Type, event.CampaignID, event.Dimensions, event.Token = claims.Type, claims.CampaignID, claims.Dimensions, ""
+
+	return claims, nil
+}
+
+// Track records the event of a beacon URL. Its token vouches for the campaign and the dimensions, so they aren't
+// validated again, and is accepted once.
+func (s EventService) Track(ctx context.Context, token string) error {
+	if s.beacons == nil {
+		return beaconError("Beacon URLs are not enabled")
+	}
+
+	now := time.Now().UTC()
+
+	claims, reason := s.verifyBeacon(token, now)
+	if claims == nil {
+		return beaconError("Beacon token " + reason)
+	}
+
+	claimed, err := s.nonces.ClaimNonce(ctx, claims.Nonce, s.beacons.ExpiresAt(claims))
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return &helpers.Error{Code: "Conflict", StatusCode: http.StatusConflict, Reason: "Beacon token was already used"}
+	}
+
+	err = s.Events.AddEvents([]models.Event{{Type: claims.Type, CampaignID: claims.CampaignID,
+		Dimensions: claims.Dimensions, Timestamp: now, ReceivedAt: now}})
+	if err != nil {
+		s.releaseNonces(ctx, []string{claims.Nonce})
+	}
+
+	return err
+}
+
+// releaseNonces gives back the nonces of events that weren't recorded. A nonce that can't be released stays used,
+// the error of the request being what the client is told.
+func (s EventService) releaseNonces(ctx context.Context, nonces []string) {
+	for _, nonce := range nonces {
+		_ = s.nonces.ReleaseNonce(ctx, nonce)
+	}
+}
+
+// verifyBeacon returns the claims of the token, or why it is rejected.
+func (s EventService) verifyBeacon(token string, now time.Time) (*models.BeaconClaims, string) {
+	claims, err := s.beacons.Verify(token, now)
+	if errors.Is(err, helpers.ErrBeaconExpired) {
+		return nil, "has expired"
+	}
+
+	if err != nil {
+		return nil, "is invalid"
+	}
+
+	return claims, ""
+}
+
+func beaconError(reason string) error {
+	return &helpers.Error{Code: "Forbidden", StatusCode: http.StatusForbidden, Reason: reason}
+}
+
+func normalizeEvent(event *models.Event) {
+	event.Type = strings.ToLower(strings.TrimSpace(event.Type))
+	event.CampaignID = strings.ToLower(strings.TrimSpace(event.CampaignID))
+	event.Timestamp = event.Timestamp.UTC()
+
+	dimensions := &event.Dimensions
+	dimensions.App = strings.ToLower(strings.TrimSpace(dimensions.App))
+	dimensions.Country = helpers.CanonicalCountry(strings.TrimSpace(dimensions.Country))
+	dimensions.OS = strings.ToLower(strings.TrimSpace(dimensions.OS))
+	dimensions.AppVersion = strings.ToLower(strings.TrimSpace(dimensions.AppVersion))
+	dimensions.OSVersion = strings.ToLower(strings.TrimSpace(dimensions.OSVersion))
+	dimensions.Device = strings.ToLower(strings.TrimSpace(dimensions.Device))
+	dimensions.Lang = strings.ToLower(strings.TrimSpace(dimensions.Lang))
+	dimensions.UserID = strings.TrimSpace(dimensions.UserID)
+}
+
+func validateEvent(event *models.Event, i int, now time.Time) error {
+	if !slices.Contains(constants.EventTypes, event.Type) {
+		return invalidParam(fmt.Sprintf("Parameter events[%d].type must be one of %s", i,
+			strings.Join(constants.EventTypes, ", ")))
+	}
+
+	if event.CampaignID == "" {
+		return invalidParam(fmt.Sprintf("Parameter events[%d].campaign_id is required", i))
+	}
+
+	if event.Timestamp.IsZero() {
+		return invalidParam(fmt.Sprintf("Parameter events[%d].timestamp is required", i))
+	}
+
+	if event.Timestamp.After(now.Add(eventClockSkew)) {
+		return invalidParam(fmt.Sprintf("Parameter events[%d].timestamp must not be in the future", i))
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func TestEventService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEvents := stores.NewMockEvents(ctrl)
+	mockLookup := stores.NewMockCampaignLookup(ctrl)
+	ctx := context.Background()
+
+	service := NewEvent(mockEvents, mockLookup, nil, nil, true)
+
+	at := time.Now().Add(-time.Minute).Truncate(time.Second)
+	full := &helpers.Error{Code: "Service Unavailable", StatusCode: http.StatusServiceUnavailable}
+
+	tests := []struct {
+		name           string
+		events         []models.Event
+		mockCalls      []interface{}
+		expectedResult *models.EventReceipt
+		expectedError  error
+	}{
+		{
+			name: "events are normalized and buffered",
+			events: []models.Event{
+				{Type: "Impression", CampaignID: " Spotify ", Timestamp: at,
+					Dimensions: models.EventDimensions{App: "com.Spotify", Country: "India", OS: "Android", UserID: "Device-1"}},
+				{Type: "click", CampaignID: "spotify", Timestamp: at},
+			},
+			mockCalls: []interface{}{
+				mockLookup.EXPECT().CampaignExists(ctx, "spotify").Return(true, nil),
+				mockEvents.EXPECT().AddEvents(gomock.Any()).DoAndReturn(func(events []models.Event) error {
+					for i := range events {
+						assert.WithinDuration(t, time.Now(), events[i].ReceivedAt, time.Minute)
+						events[i].ReceivedAt = time.Time{}
+					}
+
+					assert.Equal(t, []models.Event{
+						{Type: "impression", CampaignID: "spotify", Timestamp: at.UTC(), Dimensions: models.EventDimensions{
+							App: "com.spotify", Country: "in", OS: "android", UserID: "Device-1"}},
+						{Type: "click", CampaignID: "spotify", Timestamp: at.UTC()},
+					}, events)
+
+					return nil
+				}),
+			},
+			expectedResult: &models.EventReceipt{Accepted: 2},
+		},
+		{
+			name:          "no events",
+			events:        []models.Event{},
+			expectedError: invalidParam("Parameter events must have between 1 and 100 events"),
+		},
+		{
+			name:          "unknown type",
+			events:        []models.Event{{Type: "view", CampaignID: "spotify", Timestamp: at}},
+			expectedError: invalidParam("Parameter events[0].type must be one of click, dismiss, impression"),
+		},
+		{
+			name: "missing campaign id",
+			events: []models.Event{{Type: "click", CampaignID: "spotify", Timestamp: at},
+				{Type: "click", Timestamp: at}},
+			mockCalls: []interface{}{
+				mockLookup.EXPECT().CampaignExists(ctx, "spotify").Return(true, nil),
+			},
+			expectedError: invalidParam("Parameter events[1].campaign_id is required"),
+		},
+		{
+			name:          "missing timestamp",
+			events:        []models.Event{{Type: "click", CampaignID: "spotify"}},
+			expectedError: invalidParam("Parameter events[0].timestamp is required"),
+		},
+		{
+			name:          "timestamp in the future",
+			events:        []models.Event{{Type: "click", CampaignID: "spotify", Timestamp: at.Add(time.Hour)}},
+			expectedError: invalidParam("Parameter events[0].timestamp must not be in the future"),
+		},
+		{
+			name:   "unknown campaign",
+			events: []models.Event{{Type: "dismiss", CampaignID: "unknown", Timestamp: at}},
+			mockCalls: []interface{}{
+				mockLookup.EXPECT().CampaignExists(ctx, "unknown").Return(false, nil),
+			},
+			expectedError: invalidParam("Parameter events[0].campaign_id is not a known campaign"),
+		},
+		{
+			name:   "buffer is full",
+			events: []models.Event{{Type: "dismiss", CampaignID: "spotify", Timestamp: at}},
+			mockCalls: []interface{}{
+				mockLookup.EXPECT().CampaignExists(ctx, "spotify").Return(true, nil),
+				mockEvents.EXPECT().AddEvents(gomock.Any()).Return(full),
+			},
+			expectedError: full,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Record(ctx, tt.events)
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+
+	_, err := NewEvent(mockEvents, mockLookup, nil, nil, false).Record(ctx, []models.Event{{Type: "click",
+		CampaignID: "spotify", Timestamp: at}})
+	assert.Equal(t, beaconError("Events need a beacon token, and beacon URLs are not enabled"), err)
+}
+
+func TestEventService_RecordSigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEvents := stores.NewMockEvents(ctrl)
+	mockNonces := stores.NewMockNonces(ctrl)
+	ctx := context.Background()
+
+	beacons := helpers.NewBeaconSigner([]models.BeaconKey{{ID: "2026-10", Secret: []byte(strings.Repeat("c", 32))}},
+		time.Hour, "")
+	service := NewEvent(mockEvents, nil, mockNonces, beacons, true)
+
+	at := time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+	dimensions := models.EventDimensions{App: "com.spotify", Country: "in", OS: "android"}
+	sign := func(eventType string) (string, *models.BeaconClaims) {
+		claims := &models.BeaconClaims{Type: eventType, CampaignID: "spotify", Dimensions: dimensions,
+			IssuedAt: time.Now().Unix()}
+
+		token, err := beacons.Sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return token, claims
+	}
+
+	impression, impressionClaims := sign("impression")
+	click, clickClaims := sign("click")
+
+	// The token vouches for the campaign and the dimensions, whatever the event says, and a used token is left out.
+	gomock.InOrder(
+		mockNonces.EXPECT().ClaimNonce(ctx, impressionClaims.Nonce, gomock.Any()).Return(true, nil),
+		mockNonces.EXPECT().ClaimNonce(ctx, clickClaims.Nonce, gomock.Any()).Return(false, nil),
+		mockEvents.EXPECT().AddEvents(gomock.Any()).DoAndReturn(func(events []models.Event) error {
+			require.Len(t, events, 1)
+			events[0].ReceivedAt = time.Time{}
+			assert.Equal(t, models.Event{Type: "impression", CampaignID: "spotify", Dimensions: dimensions,
+				Timestamp: at}, events[0])
+
+			return nil
+		}),
+	)
+
+	result, err := service.Record(ctx, []models.Event{
+		{Type: "impression", CampaignID: "zepto", Timestamp: at, Token: impression},
+		{Timestamp: at, Token: click},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &models.EventReceipt{Accepted: 1, Duplicates: 1}, result)
+
+	tests := []struct {
+		name          string
+		event         models.Event
+		expectedError error
+	}{
+		{
+			name:          "missing token",
+			event:         models.Event{Type: "click", CampaignID: "spotify", Timestamp: at},
+			expectedError: invalidParam("Parameter events[0].token is required"),
+		},
+		{
+			name:          "invalid token",
+			event:         models.Event{Type: "click", Timestamp: at, Token: click + "x"},
+			expectedError: beaconError("Beacon token of events[0] is invalid"),
+		},
+		{
+			name:          "type of another token",
+			event:         models.Event{Type: "click", Timestamp: at, Token: impression},
+			expectedError: invalidParam("Parameter events[0].type must be impression like its token"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Record(ctx, []models.Event{tt.event})
+
+			assert.Nil(t, result)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestEventService_RecordRetriedAfterFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEvents := stores.NewMockEvents(ctrl)
+	mockNonces := stores.NewMockNonces(ctrl)
+	ctx := context.Background()
+
+	beacons := helpers.NewBeaconSigner([]models.BeaconKey{{ID: "2026-10", Secret: []byte(strings.Repeat("c", 32))}},
+		time.Hour, "")
+	at := time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+	sign := func(eventType string) (models.Event, *models.BeaconClaims) {
+		claims := &models.BeaconClaims{Type: eventType, CampaignID: "spotify", IssuedAt: time.Now().Unix()}
+
+		token, err := beacons.Sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return models.Event{Timestamp: at, Token: token}, claims
+	}
+
+	impression, impressionClaims := sign("impression")
+	click, clickClaims := sign("click")
+	full := &helpers.Error{Code: "Service Unavailable", StatusCode: http.StatusServiceUnavailable,
+		Reason: "Too many events"}
+	unavailable := &helpers.Error{Code: "Service Unavailable", StatusCode: http.StatusServiceUnavailable,
+		Reason: "Redis is unavailable"}
+
+	// The nonces claimed before a failing claim are released.
+	service := NewEvent(mockEvents, nil, mockNonces, beacons, false)
+	gomock.InOrder(
+		mockNonces.EXPECT().ClaimNonce(ctx, impressionClaims.Nonce, gomock.Any()).Return(true, nil),
+		mockNonces.EXPECT().ClaimNonce(ctx, clickClaims.Nonce, gomock.Any()).Return(false, unavailable),
+		mockNonces.EXPECT().ReleaseNonce(ctx, impressionClaims.Nonce).Return(nil),
+	)
+
+	_, err := service.Record(ctx, []models.Event{impression, click})
+	assert.Equal(t, unavailable, err)
+
+	// A batch the buffer rejects is accepted when retried, rather than counted as duplicates.
+	nonces := stores.New(nil, nil, helpers.InitializeLogger(), nil, nil)
+	service = NewEvent(mockEvents, nil, &nonces, beacons, false)
+
+	gomock.InOrder(
+		mockEvents.EXPECT().AddEvents(gomock.Any()).Return(full),
+		mockEvents.EXPECT().AddEvents(gomock.Len(2)).Return(nil),
+	)
+
+	_, err = service.Record(ctx, []models.Event{impression, click})
+	assert.Equal(t, full, err)
+
+	result, err := service.Record(ctx, []models.Event{impression, click})
+	assert.Nil(t, err)
+	assert.Equal(t, &models.EventReceipt{Accepted: 2}, result)
+}
+
+func TestEventService_Track(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEvents := stores.NewMockEvents(ctrl)
+	mockNonces := stores.NewMockNonces(ctrl)
+	ctx := context.Background()
+
+	previous := models.BeaconKey{ID: "2026-09", Secret: []byte(strings.Repeat("p", 32))}
+	current := models.BeaconKey{ID: "2026-10", Secret: []byte(strings.Repeat("c", 32))}
+	beacons := helpers.NewBeaconSigner([]models.BeaconKey{current, previous}, time.Hour, "")
+	service := NewEvent(mockEvents, nil, mockNonces, beacons, false)
+
+	dimensions := models.EventDimensions{App: "com.spotify", Country: "in", OS: "android"}
+	sign := func(signer *helpers.BeaconSigner, issuedAt time.Time) (string, *models.BeaconClaims) {
+		claims := &models.BeaconClaims{Type: "click", CampaignID: "spotify", Dimensions: dimensions,
+			IssuedAt: issuedAt.Unix()}
+
+		token, err := signer.Sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return token, claims
+	}
+
+	// Tokens signed with the previous key are still accepted during the rotation.
+	token, claims := sign(helpers.NewBeaconSigner([]models.BeaconKey{previous}, time.Hour, ""), time.Now())
+
+	gomock.InOrder(
+		mockNonces.EXPECT().ClaimNonce(ctx, claims.Nonce, time.Unix(claims.IssuedAt, 0).Add(time.Hour)).Return(true, nil),
+		mockEvents.EXPECT().AddEvents(gomock.Any()).DoAndReturn(func(events []models.Event) error {
+			assert.Len(t, events, 1)
+			assert.Equal(t, "click", events[0].Type)
+			assert.Equal(t, "spotify", events[0].CampaignID)
+			assert.Equal(t, dimensions, events[0].Dimensions)
+
+			return nil
+		}),
+	)
+
+	assert.Nil(t, service.Track(ctx, token))
+
+	mockNonces.EXPECT().ClaimNonce(ctx, claims.Nonce, gomock.Any()).Return(false, nil)
+
+	assert.Equal(t, &helpers.Error{Code: "Conflict", StatusCode: http.StatusConflict,
+		Reason: "Beacon token was already used"}, service.Track(ctx, token))
+
+	// A token whose event the buffer rejects can be retried.
+	token, claims = sign(beacons, time.Now())
+	full := &helpers.Error{Code: "Service Unavailable", StatusCode: http.StatusServiceUnavailable,
+		Reason: "Too many events"}
+
+	gomock.InOrder(
+		mockNonces.EXPECT().ClaimNonce(ctx, claims.Nonce, gomock.Any()).Return(true, nil),
+		mockEvents.EXPECT().AddEvents(gomock.Any()).Return(full),
+		mockNonces.EXPECT().ReleaseNonce(ctx, claims.Nonce).Return(nil),
+	)
+
+	assert.Equal(t, full, service.Track(ctx, token))
+
+	token, _ = sign(beacons, time.Now().Add(-2*time.Hour))
+
+	assert.Equal(t, beaconError("Beacon token has expired"), service.Track(ctx, token))
+
+	token, _ = sign(beacons, time.Now())
+	parts := strings.Split(token, ".")
+	tampered, _ := sign(beacons, time.Now())
+	tampered = parts[0] + "." + strings.Split(tampered, ".")[1] + "." + parts[2]
+
+	assert.Equal(t, beaconError("Beacon token is invalid"), service.Track(ctx, tampered))
+
+	token, _ = sign(helpers.NewBeaconSigner([]models.BeaconKey{{ID: "2026-10", Secret: []byte(strings.Repeat("x", 32))}},
+		time.Hour, ""), time.Now())
+
+	assert.Equal(t, beaconError("Beacon token is invalid"), service.Track(ctx, token))
+	assert.Equal(t, beaconError("Beacon token is invalid"), service.Track(ctx, ""))
+
+	assert.Equal(t, beaconError("Beacon URLs are not enabled"),
+		NewEvent(mockEvents, nil, mockNonces, nil, false).Track(ctx, token))
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+type ExportService struct {
+	campaign stores.Campaign
+	rule     stores.Rule
+}
+
+func NewExport(campaignStore stores.Campaign, ruleStore stores.Rule) ExportService {
+	return ExportService{campaign: campaignStore, rule: ruleStore}
+}
+
+// Export writes the campaigns or the rules in the format. CSV follows the stores/testdata layout so that the output
+// can be imported back, and NDJSON writes one document per line. When the entity is empty, JSON writes both
+// collections in one document and the other formats a zip archive of campaigns and rules files.
+func (s ExportService) Export(ctx context.Context, w io.Writer, format, entity string) error {
+	format, entity = strings.ToLower(strings.TrimSpace(format)), strings.ToLower(strings.TrimSpace(entity))
+
+	if format != constants.FormatCSV && format != constants.FormatJSON && format != constants.FormatNDJSON {
+		return invalidParam("Parameter format must be one of csv, json, ndjson")
+	}
+
+	if entity != "" && entity != constants.EntityCampaigns && entity != constants.EntityRules {
+		return invalidParam("Parameter entity must be one of campaigns, rules")
+	}
+
+	var (
+		campaigns []models.Campaign
+		rules     []models.TargetingRule
+		err       error
+	)
+
+	if entity != constants.EntityRules {
+		campaigns, err = s.campaign.ListCampaigns(ctx, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	if entity != constants.EntityCampaigns {
+		rules, err = s.rule.ListRules(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case format == constants.FormatJSON && entity == "":
+		return json.NewEncoder(w).Encode(map[string]interface{}{
+			constants.EntityCampaigns: campaigns,
+			constants.EntityRules:     rules,
+		})
+	case entity == "":
+		return writeArchive(w, format, campaigns, rules)
+	case format == constants.FormatJSON && entity == constants.EntityCampaigns:
+		return json.NewEncoder(w).Encode(campaigns)
+	case format == constants.FormatJSON:
+		return json.NewEncoder(w).Encode(rules)
+	case format == constants.FormatNDJSON && entity == constants.EntityCampaigns:
+		return writeNDJSON(w, campaigns)
+	case format == constants.FormatNDJSON:
+		return writeNDJSON(w, rules)
+	case entity == constants.EntityCampaigns:
+		return writeCampaignsCSV(w, campaigns)
+	default:
+		return writeRulesCSV(w, rules)
+	}
+}
+
+// writeArchive writes the campaigns and the rules in the format as the files of a zip archive, named after the
+// entities like the export command names them.
+func writeArchive(w io.Writer, format string, campaigns []models.Campaign, rules []models.TargetingRule) error {
+	archive := zip.NewWriter(w)
+
+	file, err := archive.Create(constants.EntityCampaigns + "." + format)
+	if err != nil {
+		return err
+	}
+
+	if format == constants.FormatCSV {
+		err = writeCampaignsCSV(file, campaigns)
+	} else {
+		err = writeNDJSON(file, campaigns)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if file, err = archive.Create(constants.EntityRules + "." + format); err != nil {
+		return err
+	}
+
+	if format == constants.FormatCSV {
+		err = writeRulesCSV(file, rules)
+	} else {
+		err = writeNDJSON(file, rules)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func writeNDJSON[T any](w io.Writer, documents []T) error {
+	encoder := json.NewEncoder(w)
+
+	for i := range documents {
+		if err := encoder.Encode(documents[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeCampaignsCSV(w io.Writer, campaigns []models.Campaign) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(helpers.CampaignsHeader); err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		totalGoal, dailyGoal := helpers.FormatImpressionGoal(campaign.ImpressionGoal)
+
+		err := writer.Write([]string{campaign.CampaignID, campaign.Name, campaign.Image, campaign.CTA, campaign.Status,
+			helpers.FormatScheduleTime(campaign.StartAt, campaign.Timezone),
+			helpers.FormatScheduleTime(campaign.EndAt, campaign.Timezone), campaign.Timezone,
+			strconv.Itoa(campaign.Priority), strconv.Itoa(campaign.Weight), helpers.FormatFrequencyCap(campaign.FrequencyCap),
+			totalGoal, dailyGoal})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// writeRulesCSV writes a row per dimension of every campaign, with the include and exclude values pipe separated.
+// Required dimensions without a rule are written with empty values, so that a campaign without any rule still has rows
+// and keeps being delivered once imported. The expression comes last, in the include column of its row.
+func writeRulesCSV(w io.Writer, rules []models.TargetingRule) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(helpers.RulesHeader); err != nil {
+		return err
+	}
+
+	for _, targetingRule := range rules {
+		written := make(map[string]bool)
+
+		for _, rule := range targetingRule.Rules {
+			include := strings.Join(rule.Include, "|")
+			if rule.Dimension == constants.Daypart {
+				include = helpers.FormatTimeWindows(rule.Windows)
+			}
+
+			err := writer.Write([]string{targetingRule.CampaignID, rule.Dimension, include, strings.Join(rule.Exclude, "|")})
+			if err != nil {
+				return err
+			}
+
+			written[rule.Dimension] = true
+		}
+
+		for _, dimension := range constants.RequiredDimensions {
+			if written[dimension] {
+				continue
+			}
+
+			if err := writer.Write([]string{targetingRule.CampaignID, dimension, "", ""}); err != nil {
+				return err
+			}
+		}
+
+		if targetingRule.Expression != "" {
+			err := writer.Write([]string{targetingRule.CampaignID, constants.Expression, targetingRule.Expression, ""})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
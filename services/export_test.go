@@ -0,0 +1,166 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func TestExportService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCampaign := stores.NewMockCampaign(ctrl)
+	mockRule := stores.NewMockRule(ctrl)
+	ctx := context.Background()
+
+	service := NewExport(mockCampaign, mockRule)
+
+	campaigns := []models.Campaign{{CampaignID: "spotify", Name: "Spotify Campaign",
+		Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "ACTIVE"}}
+	rules := []models.TargetingRule{{CampaignID: "spotify", Rules: []models.Rule{
+		{Dimension: "country", Include: []string{"us", "canada"}, Exclude: []string{}},
+		{Dimension: "os", Include: []string{}, Exclude: []string{"web"}},
+	}}}
+
+	tests := []struct {
+		name           string
+		format         string
+		entity         string
+		mockCalls      []interface{}
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:   "campaigns as csv",
+			format: "csv",
+			entity: "campaigns",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().ListCampaigns(ctx, "").Return(campaigns, nil),
+			},
+			expectedOutput: "CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap,TotalGoal," +
+				"DailyGoal\nspotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE,,,,0,0,,,\n",
+		},
+		{
+			name:   "rules as csv with missing dimensions",
+			format: "CSV",
+			entity: "rules",
+			mockCalls: []interface{}{
+				mockRule.EXPECT().ListRules(ctx).Return(rules, nil),
+			},
+			expectedOutput: "CampaignID,Dimension,Include,Exclude\n" +
+				"spotify,country,us|canada,\n" +
+				"spotify,os,,web\n" +
+				"spotify,app,,\n",
+		},
+		{
+			name:   "rules as csv with an expression",
+			format: "csv",
+			entity: "rules",
+			mockCalls: []interface{}{
+				mockRule.EXPECT().ListRules(ctx).Return([]models.TargetingRule{{CampaignID: "spotify",
+					Rules: []models.Rule{}, Expression: "country in (us, ca) or os = ios"}}, nil),
+			},
+			expectedOutput: "CampaignID,Dimension,Include,Exclude\n" +
+				"spotify,app,,\n" +
+				"spotify,country,,\n" +
+				"spotify,os,,\n" +
+				"spotify,expression,\"country in (us, ca) or os = ios\",\n",
+		},
+		{
+			name:   "rules as ndjson",
+			format: "ndjson",
+			entity: "rules",
+			mockCalls: []interface{}{
+				mockRule.EXPECT().ListRules(ctx).Return(rules, nil),
+			},
+			expectedOutput: `{"campaign_id":"spotify","rules":[{"dimension":"country","include":["us","canada"],"exclude":[]},` +
+				`{"dimension":"os","include":[],"exclude":["web"]}]}` + "\n",
+		},
+		{
+			name:   "both collections as json",
+			format: "json",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().ListCampaigns(ctx, "").Return(campaigns, nil),
+				mockRule.EXPECT().ListRules(ctx).Return([]models.TargetingRule{}, nil),
+			},
+			expectedOutput: `{"campaigns":[{"campaign_id":"spotify","name":"Spotify Campaign",` +
+				`"image":"https://example.com/images/spotify.png","cta":"Listen Now","status":"ACTIVE","priority":0}],"rules":[]}` + "\n",
+		},
+		{
+			name:          "unknown format",
+			format:        "xml",
+			entity:        "rules",
+			expectedError: invalidParam("Parameter format must be one of csv, json, ndjson"),
+		},
+		{
+			name:   "store returns error",
+			format: "json",
+			entity: "campaigns",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().ListCampaigns(ctx, "").Return(nil, &helpers.Error{StatusCode: http.StatusInternalServerError}),
+			},
+			expectedError: &helpers.Error{StatusCode: http.StatusInternalServerError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := service.Export(ctx, &buf, tt.format, tt.entity)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedOutput, buf.String())
+		})
+	}
+}
+
+func TestExportService_ExportArchive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCampaign := stores.NewMockCampaign(ctrl)
+	mockRule := stores.NewMockRule(ctrl)
+	ctx := context.Background()
+
+	service := NewExport(mockCampaign, mockRule)
+
+	mockCampaign.EXPECT().ListCampaigns(ctx, "").Return([]models.Campaign{{CampaignID: "spotify", Name: "Spotify Campaign",
+		Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "ACTIVE"}}, nil)
+	mockRule.EXPECT().ListRules(ctx).Return([]models.TargetingRule{{CampaignID: "spotify",
+		Rules: []models.Rule{{Dimension: "os", Include: []string{"ios"}, Exclude: []string{}}}}}, nil)
+
+	var buf bytes.Buffer
+	assert.Nil(t, service.Export(ctx, &buf, "csv", ""))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		assert.Nil(t, err)
+
+		content, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+
+		files[file.Name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"campaigns.csv": "CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap," +
+			"TotalGoal,DailyGoal\nspotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE,,,,0,0,,,\n",
+		"rules.csv": "CampaignID,Dimension,Include,Exclude\nspotify,os,ios,\nspotify,app,,\nspotify,country,,\n",
+	}, files)
+}
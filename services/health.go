@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+type HealthService struct {
+	stores.Health
+	timeout      time.Duration
+	shuttingDown *atomic.Bool
+}
+
+func NewHealth(store stores.Health, timeout time.Duration) HealthService {
+	return HealthService{Health: store, timeout: timeout, shuttingDown: &atomic.Bool{}}
+}
+
+// Ready is false while shutting down, until the targeting data is loaded and while a required dependency is down.
+// The dependencies are checked in every case, so that the response tells their state.
+func (s HealthService) Ready(ctx context.Context) *models.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	readiness := &models.Readiness{Dependencies: s.CheckDependencies(ctx)}
+
+	if s.shuttingDown.Load() {
+		readiness.Reasons = append(readiness.Reasons, "shutting down")
+	}
+
+	if !s.IndexLoaded() {
+		readiness.Reasons = append(readiness.Reasons, "targeting data not loaded")
+	}
+
+	for _, dependency := range readiness.Dependencies {
+		if dependency.Required && dependency.Status == constants.DependencyDown {
+			readiness.Reasons = append(readiness.Reasons, dependency.Name+" is down")
+		}
+	}
+
+	readiness.Ready = len(readiness.Reasons) == 0
+
+	return readiness
+}
+
+// ShutDown makes the service not ready from then on, for load balancers to stop sending requests.
+func (s HealthService) ShutDown() {
+	s.shuttingDown.Store(true)
+}
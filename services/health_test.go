@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func TestHealthService_Ready(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := stores.NewMockHealth(ctrl)
+
+	up := []models.DependencyStatus{{Name: "mongo", Status: "up", Required: true}, {Name: "redis", Status: "up"}}
+	redisDown := []models.DependencyStatus{{Name: "mongo", Status: "up", Required: true}, {Name: "redis", Status: "down"}}
+	mongoDown := []models.DependencyStatus{{Name: "mongo", Status: "down", Required: true}, {Name: "redis", Status: "up"}}
+
+	tests := []struct {
+		name         string
+		dependencies []models.DependencyStatus
+		indexLoaded  bool
+		shutDown     bool
+		expected     *models.Readiness
+	}{
+		{
+			name:         "ready",
+			dependencies: up,
+			indexLoaded:  true,
+			expected:     &models.Readiness{Ready: true, Dependencies: up},
+		},
+		{
+			name:         "optional dependency down",
+			dependencies: redisDown,
+			indexLoaded:  true,
+			expected:     &models.Readiness{Ready: true, Dependencies: redisDown},
+		},
+		{
+			name:         "required dependency down",
+			dependencies: mongoDown,
+			indexLoaded:  true,
+			expected:     &models.Readiness{Reasons: []string{"mongo is down"}, Dependencies: mongoDown},
+		},
+		{
+			name:         "targeting data not loaded",
+			dependencies: up,
+			expected:     &models.Readiness{Reasons: []string{"targeting data not loaded"}, Dependencies: up},
+		},
+		{
+			name:         "shutting down",
+			dependencies: up,
+			indexLoaded:  true,
+			shutDown:     true,
+			expected:     &models.Readiness{Reasons: []string{"shutting down"}, Dependencies: up},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewHealth(mockStore, time.Second)
+			if tt.shutDown {
+				service.ShutDown()
+			}
+
+			mockStore.EXPECT().CheckDependencies(gomock.Any()).Return(tt.dependencies)
+			mockStore.EXPECT().IndexLoaded().Return(tt.indexLoaded)
+
+			assert.Equal(t, tt.expected, service.Ready(context.Background()))
+		})
+	}
+}
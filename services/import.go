@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+const (
+	campaignsFile = constants.EntityCampaigns
+	rulesFile     = constants.EntityRules
+
+	actionCreate    = "create"
+	actionUpdate    = "update"
+	actionUnchanged = "unchanged"
+)
+
+type ImportService struct {
+	importer stores.Importer
+	campaign stores.Campaign
+	rule     stores.Rule
+}
+
+func NewImport(importStore stores.Importer, campaignStore stores.Campaign, ruleStore stores.Rule) ImportService {
+	return ImportService{importer: importStore, campaign: campaignStore, rule: ruleStore}
+}
+
+// Import reads campaigns and rules in the stores/testdata CSV layout, include and exclude values are pipe separated.
+// Either reader can be nil. Every row is validated first and nothing is written if any of them is invalid, the
+// campaigns are then upserted one at a time, each along with its rules, and delivery is refreshed once for all of
+// them. Rules listed for a campaign replace all its existing rules, campaigns without rule rows keep their rules.
+// Stored campaigns keep the fields of the optional columns the campaigns file doesn't have.
+func (s ImportService) Import(ctx context.Context, campaignsCSV, rulesCSV io.Reader, dryRun bool) (*models.ImportReport, error) {
+	if campaignsCSV == nil && rulesCSV == nil {
+		return nil, invalidParam("At least one of campaigns or rules file is required")
+	}
+
+	report := &models.ImportReport{DryRun: dryRun, Errors: []models.ImportError{}, Changes: []models.ImportChange{}}
+
+	campaigns, columns, err := parseCampaigns(campaignsCSV, report)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := parseRules(rulesCSV, report)
+	if err != nil {
+		return nil, err
+	}
+
+	campaignIDs := make([]string, 0, len(campaigns)+len(rules))
+	for campaignID := range campaigns {
+		campaignIDs = append(campaignIDs, campaignID)
+	}
+
+	for campaignID := range rules {
+		if _, ok := campaigns[campaignID]; !ok {
+			campaignIDs = append(campaignIDs, campaignID)
+		}
+	}
+
+	sort.Strings(campaignIDs)
+
+	for _, campaignID := range campaignIDs {
+		changes, err := s.diff(ctx, campaignID, campaigns[campaignID], columns, rules[campaignID], report)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Changes = append(report.Changes, changes...)
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	var imports []models.CampaignImport
+	for _, campaignID := range campaignIDs {
+		if !hasChanges(report.Changes, campaignID) {
+			continue
+		}
+
+		imports = append(imports, models.CampaignImport{Campaign: campaigns[campaignID].Campaign,
+			Rule: rules[campaignID].Rule})
+	}
+
+	if len(imports) == 0 {
+		return report, nil
+	}
+
+	if err := s.importer.ImportCampaigns(ctx, imports); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// parseCampaigns reads the campaigns file, reporting its invalid rows, and returns its campaigns by id along with
+// its columns.
+func parseCampaigns(r io.Reader, report *models.ImportReport) (map[string]helpers.CampaignRow, []string, error) {
+	file, err := helpers.ReadCampaignsCSV(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report.Errors = append(report.Errors, file.Errors...)
+
+	campaigns := make(map[string]helpers.CampaignRow)
+	for _, row := range file.Campaigns {
+		campaigns[row.Campaign.CampaignID] = row
+	}
+
+	return campaigns, file.Columns, nil
+}
+
+// parseRules reads the rules file, reporting its invalid rows, and returns the rules of its campaigns by id.
+func parseRules(r io.Reader, report *models.ImportReport) (map[string]helpers.RulesRow, error) {
+	file, err := helpers.ReadRulesCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Errors = append(report.Errors, file.Errors...)
+
+	rules := make(map[string]helpers.RulesRow)
+	for _, row := range file.Rules {
+		rules[row.Rule.CampaignID] = row
+	}
+
+	return rules, nil
+}
+
+// diff compares the imported campaign and rules with the stored ones. A campaign that is neither imported nor
+// stored is reported as a row error of its rules. An imported campaign that is stored takes the fields of the columns
+// the file doesn't have from the stored one, and is validated again along with them.
+func (s ImportService) diff(ctx context.Context, campaignID string, campaign helpers.CampaignRow, columns []string,
+	rules helpers.RulesRow, report *models.ImportReport) ([]models.ImportChange, error) {
+	var changes []models.ImportChange
+
+	existingCampaign, err := s.campaign.GetCampaign(ctx, campaignID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	if campaign.Campaign == nil && existingCampaign == nil {
+		report.Errors = append(report.Errors, models.ImportError{File: rulesFile, Row: rules.Row, CampaignID: campaignID,
+			Reason: "Campaign " + campaignID + " is neither in the campaigns file nor stored"})
+
+		return nil, nil
+	}
+
+	if campaign.Campaign != nil && existingCampaign != nil {
+		keepMissingColumns(campaign.Campaign, existingCampaign, columns)
+
+		if err := helpers.ValidateCampaign(campaign.Campaign); err != nil {
+			report.Errors = append(report.Errors, models.ImportError{File: campaignsFile, Row: campaign.Row,
+				CampaignID: campaignID, Reason: err.Error()})
+			campaign.Campaign = nil
+		}
+	}
+
+	if campaign.Campaign != nil {
+		changes = append(changes, diffCampaign(existingCampaign, campaign.Campaign))
+	}
+
+	if rules.Rule != nil {
+		existingRule, err := s.rule.GetRules(ctx, campaignID)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+
+		changes = append(changes, diffRules(campaignID, existingRule, rules.Rule.Rules, rules.Rule.Expression))
+	}
+
+	return changes, nil
+}
+
+// keepMissingColumns sets the fields of the optional columns the file doesn't have to the ones of the stored campaign,
+// so that importing a file without them doesn't clear what was set through the API.
+func keepMissingColumns(campaign, existing *models.Campaign, columns []string) {
+	if !slices.Contains(columns, "StartAt") {
+		campaign.StartAt = existing.StartAt
+	}
+
+	if !slices.Contains(columns, "EndAt") {
+		campaign.EndAt = existing.EndAt
+	}
+
+	if !slices.Contains(columns, "Timezone") {
+		campaign.Timezone = existing.Timezone
+	}
+
+	if !slices.Contains(columns, "Priority") {
+		campaign.Priority = existing.Priority
+	}
+
+	if !slices.Contains(columns, "Weight") {
+		campaign.Weight = existing.Weight
+	}
+
+	if !slices.Contains(columns, "FrequencyCap") {
+		campaign.FrequencyCap = existing.FrequencyCap
+	}
+
+	var goal, existingGoal models.ImpressionGoal
+	if campaign.ImpressionGoal != nil {
+		goal = *campaign.ImpressionGoal
+	}
+
+	if existing.ImpressionGoal != nil {
+		existingGoal = *existing.ImpressionGoal
+	}
+
+	if !slices.Contains(columns, "TotalGoal") {
+		goal.Total = existingGoal.Total
+	}
+
+	if !slices.Contains(columns, "DailyGoal") {
+		goal.Daily = existingGoal.Daily
+	}
+
+	campaign.ImpressionGoal = nil
+	if goal.Total != 0 || goal.Daily != 0 {
+		campaign.ImpressionGoal = &goal
+	}
+}
+
+func diffCampaign(existing, imported *models.Campaign) models.ImportChange {
+	change := models.ImportChange{CampaignID: imported.CampaignID, Entity: campaignsFile, Action: actionCreate}
+	if existing == nil {
+		return change
+	}
+
+	existingTotal, existingDaily := helpers.FormatImpressionGoal(existing.ImpressionGoal)
+	importedTotal, importedDaily := helpers.FormatImpressionGoal(imported.ImpressionGoal)
+
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"name", existing.Name, imported.Name},
+		{"image", existing.Image, imported.Image},
+		{"cta", existing.CTA, imported.CTA},
+		{"status", existing.Status, imported.Status},
+		{"start_at", helpers.FormatScheduleTime(existing.StartAt, ""), helpers.FormatScheduleTime(imported.StartAt, "")},
+		{"end_at", helpers.FormatScheduleTime(existing.EndAt, ""), helpers.FormatScheduleTime(imported.EndAt, "")},
+		{"timezone", existing.Timezone, imported.Timezone},
+		{"priority", strconv.Itoa(existing.Priority), strconv.Itoa(imported.Priority)},
+		{"weight", strconv.Itoa(existing.Weight), strconv.Itoa(imported.Weight)},
+		{"frequency_cap", helpers.FormatFrequencyCap(existing.FrequencyCap), helpers.FormatFrequencyCap(imported.FrequencyCap)},
+		{"total_goal", existingTotal, importedTotal},
+		{"daily_goal", existingDaily, importedDaily},
+	}
+
+	for _, field := range fields {
+		if field.old != field.new {
+			change.Diff = append(change.Diff, fmt.Sprintf("%s: %q -> %q", field.name, field.old, field.new))
+		}
+	}
+
+	change.Action = actionUnchanged
+	if len(change.Diff) > 0 {
+		change.Action = actionUpdate
+	}
+
+	return change
+}
+
+// diffRules compares rules per dimension, a missing dimension being the same as one with empty lists, and the
+// expressions.
+func diffRules(campaignID string, existing *models.TargetingRule, imported []models.Rule,
+	expression string) models.ImportChange {
+	change := models.ImportChange{CampaignID: campaignID, Entity: rulesFile, Action: actionCreate}
+	if existing == nil {
+		return change
+	}
+
+	oldRules := describeRules(existing.Rules, existing.Expression)
+	newRules := describeRules(imported, expression)
+
+	dimensions := make([]string, 0, len(oldRules)+len(newRules))
+	for dimension := range oldRules {
+		dimensions = append(dimensions, dimension)
+	}
+
+	for dimension := range newRules {
+		if _, ok := oldRules[dimension]; !ok {
+			dimensions = append(dimensions, dimension)
+		}
+	}
+
+	sort.Strings(dimensions)
+
+	for _, dimension := range dimensions {
+		if oldRules[dimension] != newRules[dimension] {
+			change.Diff = append(change.Diff, fmt.Sprintf("%s: %s -> %s", dimension,
+				describeOrNone(oldRules[dimension]), describeOrNone(newRules[dimension])))
+		}
+	}
+
+	change.Action = actionUnchanged
+	if len(change.Diff) > 0 {
+		change.Action = actionUpdate
+	}
+
+	return change
+}
+
+func describeRules(rules []models.Rule, expression string) map[string]string {
+	described := make(map[string]string)
+	if expression != "" {
+		described[constants.Expression] = strconv.Quote(expression)
+	}
+
+	for _, rule := range rules {
+		if len(rule.Include) == 0 && len(rule.Exclude) == 0 && len(rule.Windows) == 0 {
+			continue
+		}
+
+		if rule.Dimension == constants.Daypart {
+			described[rule.Dimension] = "windows=[" + helpers.FormatTimeWindows(rule.Windows) + "]"
+			continue
+		}
+
+		include, exclude := slices.Clone(rule.Include), slices.Clone(rule.Exclude)
+		sort.Strings(include)
+		sort.Strings(exclude)
+
+		described[rule.Dimension] = "include=[" + strings.Join(include, "|") + "] exclude=[" + strings.Join(exclude, "|") + "]"
+	}
+
+	return described
+}
+
+func describeOrNone(description string) string {
+	if description == "" {
+		return "none"
+	}
+
+	return description
+}
+
+func hasChanges(changes []models.ImportChange, campaignID string) bool {
+	for _, change := range changes {
+		if change.CampaignID == campaignID && change.Action != actionUnchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNotFound(err error) bool {
+	var parsedErr *helpers.Error
+
+	return errors.As(err, &parsedErr) && parsedErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func TestImportService_ImportTestdata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImport := stores.NewMockImporter(ctrl)
+	mockCampaign := stores.NewMockCampaign(ctrl)
+	mockRule := stores.NewMockRule(ctrl)
+	ctx := context.Background()
+
+	service := NewImport(mockImport, mockCampaign, mockRule)
+
+	campaignsCSV, err := os.Open("../stores/testdata/campaigns.csv")
+	assert.Nil(t, err)
+	defer campaignsCSV.Close()
+
+	rulesCSV, err := os.Open("../stores/testdata/rules.csv")
+	assert.Nil(t, err)
+	defer rulesCSV.Close()
+
+	notFound := &helpers.Error{StatusCode: http.StatusNotFound}
+	mockCampaign.EXPECT().GetCampaign(ctx, gomock.Any()).Return(nil, notFound).Times(11)
+	mockRule.EXPECT().GetRules(ctx, gomock.Any()).Return(nil, notFound).Times(11)
+	var imports []models.CampaignImport
+	mockImport.EXPECT().ImportCampaigns(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, batch []models.CampaignImport) error {
+			imports = batch
+			return nil
+		})
+
+	report, err := service.Import(ctx, campaignsCSV, rulesCSV, false)
+
+	assert.Nil(t, err)
+	assert.Empty(t, report.Errors)
+	assert.Len(t, report.Changes, 22)
+	assert.Equal(t, models.ImportChange{CampaignID: "amazonprime", Entity: "campaigns", Action: "create"}, report.Changes[0])
+
+	// Every campaign is written in the one batch, delivery being refreshed once.
+	assert.Len(t, imports, 11)
+	assert.Contains(t, imports, models.CampaignImport{Campaign: &models.Campaign{CampaignID: "netflix",
+		Name: "Netflix Streaming Service", Image: "https://example.com/images/netflix.png", CTA: "Watch Now",
+		Status: "ACTIVE"}, Rule: &models.TargetingRule{CampaignID: "netflix", Rules: []models.Rule{
+		{Dimension: "os", Include: []string{"ios"}, Exclude: []string{}},
+		{Dimension: "country", Include: []string{"gb", "de", "fr"}, Exclude: []string{"in"}},
+		{Dimension: "app", Include: []string{}, Exclude: []string{}},
+	}}})
+}
+
+func TestImportService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImport := stores.NewMockImporter(ctrl)
+	mockCampaign := stores.NewMockCampaign(ctrl)
+	mockRule := stores.NewMockRule(ctrl)
+	ctx := context.Background()
+
+	service := NewImport(mockImport, mockCampaign, mockRule)
+
+	spotify := &models.Campaign{CampaignID: "spotify", Name: "Spotify Campaign",
+		Image: "https://example.com/images/spotify.png", CTA: "Listen Now", Status: "ACTIVE"}
+
+	startAt, endAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	scheduled := &models.Campaign{CampaignID: "duolingo", Name: "Duolingo", Image: "https://example.com/images/duolingo.png",
+		CTA: "Learn Now", Status: "ACTIVE", StartAt: &startAt, EndAt: &endAt, Timezone: "Asia/Kolkata", Priority: 5,
+		Weight: 2, FrequencyCap: &models.FrequencyCap{Impressions: 3, Window: "24h"},
+		ImpressionGoal: &models.ImpressionGoal{Total: 1000, Daily: 100}}
+
+	tests := []struct {
+		name           string
+		campaignsCSV   string
+		rulesCSV       string
+		dryRun         bool
+		mockCalls      []interface{}
+		expectedResult *models.ImportReport
+		expectedError  error
+	}{
+		{
+			name:         "missing header",
+			campaignsCSV: "spotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE\n",
+			expectedError: invalidParam("The campaigns file must start with a header of the columns CampaignID,Name,Image,CTA,Status and " +
+				"optionally StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap,TotalGoal,DailyGoal"),
+		},
+		{
+			name: "row level errors are reported and nothing is written",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status\n" +
+				"spotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,PAUSED\n" +
+				"duolingo,Duolingo\n",
+			rulesCSV: "CampaignID,Dimension,Include,Exclude\n" +
+				"spotify,city,paris,\n" +
+				"spotify,os,ios,ios\n",
+			expectedResult: &models.ImportReport{Errors: []models.ImportError{
+				{File: "campaigns", Row: 2, CampaignID: "spotify", Reason: "Parameter status must be one of ACTIVE, INACTIVE"},
+				{File: "campaigns", Row: 3, Reason: "Expected 5 columns, found 2"},
+				{File: "rules", Row: 2, CampaignID: "spotify", Reason: "Unknown dimension 'city', must be one of app, country, os, app_version, os_version, device, lang, daypart"},
+				{File: "rules", Row: 3, CampaignID: "spotify", Reason: "Value 'ios' of dimension 'os' is both included and excluded"},
+			}, Changes: []models.ImportChange{}},
+		},
+		{
+			name:     "rules of an unknown campaign",
+			rulesCSV: "CampaignID,Dimension,Include,Exclude\nunknown,os,ios,\n",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "unknown").Return(nil, &helpers.Error{StatusCode: http.StatusNotFound}),
+			},
+			expectedResult: &models.ImportReport{Errors: []models.ImportError{
+				{File: "rules", Row: 2, CampaignID: "unknown", Reason: "Campaign unknown is neither in the campaigns file nor stored"},
+			}, Changes: []models.ImportChange{}},
+		},
+		{
+			name: "dry run reports the diff",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status\n" +
+				"spotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,INACTIVE\n",
+			rulesCSV: "CampaignID,Dimension,Include,Exclude\n" +
+				"spotify,country,US|Canada,\n" +
+				"spotify,os,,\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+				mockRule.EXPECT().GetRules(ctx, "spotify").Return(&models.TargetingRule{CampaignID: "spotify",
+					Rules: []models.Rule{{Dimension: "country", Include: []string{"ca", "us"}}, {Dimension: "os", Include: []string{"ios"}}}}, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "campaigns", Action: "update", Diff: []string{`status: "ACTIVE" -> "INACTIVE"`}},
+				{CampaignID: "spotify", Entity: "rules", Action: "update", Diff: []string{"os: include=[ios] exclude=[] -> none"}},
+			}},
+		},
+		{
+			name: "schedule columns are parsed in the campaign timezone",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone\n" +
+				"spotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE,2026-03-01 09:00,,Asia/Kolkata\n" +
+				"duolingo,Duolingo,https://example.com/images/duolingo.png,Learn Now,ACTIVE,tomorrow,,\n" +
+				"tinder,Tinder,https://example.com/images/tinder.png,Swipe Now,ACTIVE,,,Mars/Olympus\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{
+				{File: "campaigns", Row: 3, CampaignID: "duolingo", Reason: "Parameter start_at is invalid: invalid time tomorrow, expected RFC3339 or 2006-01-02 15:04:05"},
+				{File: "campaigns", Row: 4, CampaignID: "tinder", Reason: "Parameter timezone must be an IANA time zone, like Asia/Kolkata"},
+			}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "campaigns", Action: "update",
+					Diff: []string{`start_at: "" -> "2026-03-01T03:30:00Z"`, `timezone: "" -> "Asia/Kolkata"`}},
+			}},
+		},
+		{
+			name: "ranking columns",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight\n" +
+				"spotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE,,,,10,\n" +
+				"duolingo,Duolingo,https://example.com/images/duolingo.png,Learn Now,ACTIVE,,,,high,\n" +
+				"tinder,Tinder,https://example.com/images/tinder.png,Swipe Now,ACTIVE,,,,1,-2\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{
+				{File: "campaigns", Row: 3, CampaignID: "duolingo", Reason: "Parameter priority must be an integer"},
+				{File: "campaigns", Row: 4, CampaignID: "tinder", Reason: "Parameter weight must not be negative"},
+			}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "campaigns", Action: "update", Diff: []string{`priority: "0" -> "10"`}},
+			}},
+		},
+		{
+			name: "frequency cap column",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap\n" +
+				"spotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE,,,,0,0,3/24H\n" +
+				"duolingo,Duolingo,https://example.com/images/duolingo.png,Learn Now,ACTIVE,,,,0,0,3 a day\n" +
+				"tinder,Tinder,https://example.com/images/tinder.png,Swipe Now,ACTIVE,,,,0,0,0/1h\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{
+				{File: "campaigns", Row: 3, CampaignID: "duolingo", Reason: "Parameter frequency_cap is invalid: " +
+					"invalid frequency cap 3 a day, expected impressions/window like 3/24h"},
+				{File: "campaigns", Row: 4, CampaignID: "tinder",
+					Reason: "Parameter frequency_cap must have at least 1 impression and a window like 24h"},
+			}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "campaigns", Action: "update", Diff: []string{`frequency_cap: "" -> "3/24h"`}},
+			}},
+		},
+		{
+			name: "impression goal columns",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap,TotalGoal," +
+				"DailyGoal\nspotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE,,,,0,0,,100000,5000\n" +
+				"duolingo,Duolingo,https://example.com/images/duolingo.png,Learn Now,ACTIVE,,,,0,0,,lots,\n" +
+				"tinder,Tinder,https://example.com/images/tinder.png,Swipe Now,ACTIVE,,,,0,0,,-10,\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{
+				{File: "campaigns", Row: 3, CampaignID: "duolingo", Reason: "Parameter impression_goal is invalid: " +
+					"invalid impression goal lots, expected a number of impressions"},
+				{File: "campaigns", Row: 4, CampaignID: "tinder",
+					Reason: "Parameter impression_goal must have a positive total or daily goal"},
+			}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "campaigns", Action: "update",
+					Diff: []string{`total_goal: "" -> "100000"`, `daily_goal: "" -> "5000"`}},
+			}},
+		},
+		{
+			name: "daypart windows are read from the include column",
+			rulesCSV: "CampaignID,Dimension,Include,Exclude\n" +
+				"spotify,daypart,mon-wed 18-22|sat 22-2,\n" +
+				"spotify,app,/com\\.(google|android)\\..+/|com.whatsapp,\n" +
+				"duolingo,daypart,weekends 10-14,\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+				mockRule.EXPECT().GetRules(ctx, "spotify").Return(&models.TargetingRule{CampaignID: "spotify",
+					Rules: []models.Rule{{Dimension: "daypart", Windows: []models.TimeWindow{{Start: 18, End: 22}}}}}, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{
+				{File: "rules", Row: 4, CampaignID: "duolingo",
+					Reason: "Parameter windows is invalid: invalid days weekends, expected names like mon or ranges like mon-fri"},
+			}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "rules", Action: "update",
+					Diff: []string{`app: none -> include=[/com\.(google|android)\..+/|com.whatsapp] exclude=[]`,
+						"daypart: windows=[18-22] -> windows=[mon,tue,wed 18-22|sat 22-2]"}},
+			}},
+		},
+		{
+			name: "expressions are read from the include column",
+			rulesCSV: "CampaignID,Dimension,Include,Exclude\n" +
+				"spotify,expression,\"Country IN (US, CA) or OS = iOS\",\n" +
+				"spotify,os,android|ios,\n" +
+				"duolingo,expression,country = ,\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+				mockRule.EXPECT().GetRules(ctx, "spotify").Return(&models.TargetingRule{CampaignID: "spotify",
+					Rules: []models.Rule{{Dimension: "os", Include: []string{"ios", "android"}}}, Expression: "os = ios"}, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{
+				{File: "rules", Row: 4, CampaignID: "duolingo",
+					Reason: "Parameter expression is invalid: expected a value after = at the end"},
+			}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "rules", Action: "update",
+					Diff: []string{`expression: "os = ios" -> "country in (us, ca) or os = ios"`}},
+			}},
+		},
+		{
+			name: "stored campaigns keep the fields of the columns the file doesn't have",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status,Priority,TotalGoal\n" +
+				"duolingo,Duolingo Plus,https://example.com/images/duolingo.png,Learn Now,ACTIVE,7,2000\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "duolingo").Return(scheduled, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{}, Changes: []models.ImportChange{
+				{CampaignID: "duolingo", Entity: "campaigns", Action: "update",
+					Diff: []string{`name: "Duolingo" -> "Duolingo Plus"`, `priority: "5" -> "7"`, `total_goal: "1000" -> "2000"`}},
+			}},
+		},
+		{
+			name: "stored campaigns are validated along with the fields they keep",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status,StartAt\n" +
+				"duolingo,Duolingo,https://example.com/images/duolingo.png,Learn Now,ACTIVE,2026-05-01T00:00:00Z\n",
+			dryRun: true,
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "duolingo").Return(scheduled, nil),
+			},
+			expectedResult: &models.ImportReport{DryRun: true, Errors: []models.ImportError{
+				{File: "campaigns", Row: 2, CampaignID: "duolingo", Reason: "Parameter end_at must be after start_at"},
+			}, Changes: []models.ImportChange{}},
+		},
+		{
+			name: "unchanged campaigns are not written",
+			campaignsCSV: "CampaignID,Name,Image,CTA,Status\n" +
+				"spotify,Spotify Campaign,https://example.com/images/spotify.png,Listen Now,ACTIVE\n",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(spotify, nil),
+			},
+			expectedResult: &models.ImportReport{Errors: []models.ImportError{}, Changes: []models.ImportChange{
+				{CampaignID: "spotify", Entity: "campaigns", Action: "unchanged"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var campaignsCSV, rulesCSV *strings.Reader
+			if tt.campaignsCSV != "" {
+				campaignsCSV = strings.NewReader(tt.campaignsCSV)
+			}
+
+			if tt.rulesCSV != "" {
+				rulesCSV = strings.NewReader(tt.rulesCSV)
+			}
+
+			result, err := service.Import(ctx, readerOrNil(campaignsCSV), readerOrNil(rulesCSV), tt.dryRun)
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func readerOrNil(r *strings.Reader) io.Reader {
+	if r == nil {
+		return nil
+	}
+
+	return r
+}
@@ -1,10 +1,50 @@
 package services
 
 import (
+	"context"
+	"io"
+
 	"github.com/Durga-Chikkala/delivery-service/models"
 	"github.com/gin-gonic/gin"
 )
 
 type Delivery interface {
-	Get(ctx *gin.Context, dimensions *models.Dimension) (*[]models.Response, error)
+	Get(ctx *gin.Context, dimensions *models.Dimension, limit int) (*[]models.Response, error)
+	Explain(ctx context.Context, dimensions *models.Dimension, campaignID string) ([]models.Explanation, error)
+}
+
+type Campaign interface {
+	Create(ctx context.Context, campaign *models.Campaign) (*models.Campaign, error)
+	GetByID(ctx context.Context, campaignID string) (*models.Campaign, error)
+	GetAll(ctx context.Context, status string) ([]models.Campaign, error)
+	Update(ctx context.Context, campaignID string, campaign *models.Campaign) (*models.Campaign, error)
+	Patch(ctx context.Context, campaignID string, patch *models.CampaignPatch) (*models.Campaign, error)
+	Delete(ctx context.Context, campaignID string) error
+}
+
+type Rule interface {
+	Get(ctx context.Context, campaignID string) (*models.TargetingRule, error)
+	Save(ctx context.Context, campaignID string, rules []models.Rule, expression string) (*models.TargetingRule, error)
+	Delete(ctx context.Context, campaignID string) error
+}
+
+type Import interface {
+	Import(ctx context.Context, campaignsCSV, rulesCSV io.Reader, dryRun bool) (*models.ImportReport, error)
+}
+
+type Export interface {
+	Export(ctx context.Context, w io.Writer, format, entity string) error
+}
+
+type Event interface {
+	Record(ctx context.Context, events []models.Event) (*models.EventReceipt, error)
+	Track(ctx context.Context, token string) error
+}
+
+type Pacing interface {
+	Get(ctx context.Context, campaignID string) ([]models.Pacing, error)
+}
+
+type Health interface {
+	Ready(ctx context.Context) *models.Readiness
 }
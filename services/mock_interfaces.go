@@ -5,6 +5,8 @@
 package services
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	models "github.com/Durga-Chikkala/delivery-service/models"
@@ -35,17 +37,413 @@ func (m *MockDelivery) EXPECT() *MockDeliveryMockRecorder {
 	return m.recorder
 }
 
+// Explain mocks base method.
+func (m *MockDelivery) Explain(ctx context.Context, dimensions *models.Dimension, campaignID string) ([]models.Explanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Explain", ctx, dimensions, campaignID)
+	ret0, _ := ret[0].([]models.Explanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Explain indicates an expected call of Explain.
+func (mr *MockDeliveryMockRecorder) Explain(ctx, dimensions, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Explain", reflect.TypeOf((*MockDelivery)(nil).Explain), ctx, dimensions, campaignID)
+}
+
 // Get mocks base method.
-func (m *MockDelivery) Get(ctx *gin.Context, dimensions *models.Dimension) (*[]models.Response, error) {
+func (m *MockDelivery) Get(ctx *gin.Context, dimensions *models.Dimension, limit int) (*[]models.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, dimensions)
+	ret := m.ctrl.Call(m, "Get", ctx, dimensions, limit)
 	ret0, _ := ret[0].(*[]models.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockDeliveryMockRecorder) Get(ctx, dimensions interface{}) *gomock.Call {
+func (mr *MockDeliveryMockRecorder) Get(ctx, dimensions, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDelivery)(nil).Get), ctx, dimensions, limit)
+}
+
+// MockCampaign is a mock of Campaign interface.
+type MockCampaign struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignMockRecorder
+}
+
+// MockCampaignMockRecorder is the mock recorder for MockCampaign.
+type MockCampaignMockRecorder struct {
+	mock *MockCampaign
+}
+
+// NewMockCampaign creates a new mock instance.
+func NewMockCampaign(ctrl *gomock.Controller) *MockCampaign {
+	mock := &MockCampaign{ctrl: ctrl}
+	mock.recorder = &MockCampaignMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaign) EXPECT() *MockCampaignMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCampaign) Create(ctx context.Context, campaign *models.Campaign) (*models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, campaign)
+	ret0, _ := ret[0].(*models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCampaignMockRecorder) Create(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCampaign)(nil).Create), ctx, campaign)
+}
+
+// Delete mocks base method.
+func (m *MockCampaign) Delete(ctx context.Context, campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCampaignMockRecorder) Delete(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCampaign)(nil).Delete), ctx, campaignID)
+}
+
+// GetAll mocks base method.
+func (m *MockCampaign) GetAll(ctx context.Context, status string) ([]models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, status)
+	ret0, _ := ret[0].([]models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCampaignMockRecorder) GetAll(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCampaign)(nil).GetAll), ctx, status)
+}
+
+// GetByID mocks base method.
+func (m *MockCampaign) GetByID(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, campaignID)
+	ret0, _ := ret[0].(*models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCampaignMockRecorder) GetByID(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCampaign)(nil).GetByID), ctx, campaignID)
+}
+
+// Patch mocks base method.
+func (m *MockCampaign) Patch(ctx context.Context, campaignID string, patch *models.CampaignPatch) (*models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, campaignID, patch)
+	ret0, _ := ret[0].(*models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockCampaignMockRecorder) Patch(ctx, campaignID, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockCampaign)(nil).Patch), ctx, campaignID, patch)
+}
+
+// Update mocks base method.
+func (m *MockCampaign) Update(ctx context.Context, campaignID string, campaign *models.Campaign) (*models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, campaignID, campaign)
+	ret0, _ := ret[0].(*models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCampaignMockRecorder) Update(ctx, campaignID, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCampaign)(nil).Update), ctx, campaignID, campaign)
+}
+
+// MockRule is a mock of Rule interface.
+type MockRule struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleMockRecorder
+}
+
+// MockRuleMockRecorder is the mock recorder for MockRule.
+type MockRuleMockRecorder struct {
+	mock *MockRule
+}
+
+// NewMockRule creates a new mock instance.
+func NewMockRule(ctrl *gomock.Controller) *MockRule {
+	mock := &MockRule{ctrl: ctrl}
+	mock.recorder = &MockRuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRule) EXPECT() *MockRuleMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRule) Delete(ctx context.Context, campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRuleMockRecorder) Delete(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRule)(nil).Delete), ctx, campaignID)
+}
+
+// Get mocks base method.
+func (m *MockRule) Get(ctx context.Context, campaignID string) (*models.TargetingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, campaignID)
+	ret0, _ := ret[0].(*models.TargetingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRuleMockRecorder) Get(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRule)(nil).Get), ctx, campaignID)
+}
+
+// Save mocks base method.
+func (m *MockRule) Save(ctx context.Context, campaignID string, rules []models.Rule, expression string) (*models.TargetingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, campaignID, rules, expression)
+	ret0, _ := ret[0].(*models.TargetingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRuleMockRecorder) Save(ctx, campaignID, rules, expression interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRule)(nil).Save), ctx, campaignID, rules, expression)
+}
+
+// MockImport is a mock of Import interface.
+type MockImport struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportMockRecorder
+}
+
+// MockImportMockRecorder is the mock recorder for MockImport.
+type MockImportMockRecorder struct {
+	mock *MockImport
+}
+
+// NewMockImport creates a new mock instance.
+func NewMockImport(ctrl *gomock.Controller) *MockImport {
+	mock := &MockImport{ctrl: ctrl}
+	mock.recorder = &MockImportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImport) EXPECT() *MockImportMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockImport) Import(ctx context.Context, campaignsCSV, rulesCSV io.Reader, dryRun bool) (*models.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, campaignsCSV, rulesCSV, dryRun)
+	ret0, _ := ret[0].(*models.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockImportMockRecorder) Import(ctx, campaignsCSV, rulesCSV, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImport)(nil).Import), ctx, campaignsCSV, rulesCSV, dryRun)
+}
+
+// MockExport is a mock of Export interface.
+type MockExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportMockRecorder
+}
+
+// MockExportMockRecorder is the mock recorder for MockExport.
+type MockExportMockRecorder struct {
+	mock *MockExport
+}
+
+// NewMockExport creates a new mock instance.
+func NewMockExport(ctrl *gomock.Controller) *MockExport {
+	mock := &MockExport{ctrl: ctrl}
+	mock.recorder = &MockExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExport) EXPECT() *MockExportMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockExport) Export(ctx context.Context, w io.Writer, format, entity string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w, format, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockExportMockRecorder) Export(ctx, w, format, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockExport)(nil).Export), ctx, w, format, entity)
+}
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockEvent) Record(ctx context.Context, events []models.Event) (*models.EventReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, events)
+	ret0, _ := ret[0].(*models.EventReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockEventMockRecorder) Record(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockEvent)(nil).Record), ctx, events)
+}
+
+// Track mocks base method.
+func (m *MockEvent) Track(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockEventMockRecorder) Track(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockEvent)(nil).Track), ctx, token)
+}
+
+// MockPacing is a mock of Pacing interface.
+type MockPacing struct {
+	ctrl     *gomock.Controller
+	recorder *MockPacingMockRecorder
+}
+
+// MockPacingMockRecorder is the mock recorder for MockPacing.
+type MockPacingMockRecorder struct {
+	mock *MockPacing
+}
+
+// NewMockPacing creates a new mock instance.
+func NewMockPacing(ctrl *gomock.Controller) *MockPacing {
+	mock := &MockPacing{ctrl: ctrl}
+	mock.recorder = &MockPacingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPacing) EXPECT() *MockPacingMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPacing) Get(ctx context.Context, campaignID string) ([]models.Pacing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, campaignID)
+	ret0, _ := ret[0].([]models.Pacing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPacingMockRecorder) Get(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPacing)(nil).Get), ctx, campaignID)
+}
+
+// MockHealth is a mock of Health interface.
+type MockHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthMockRecorder
+}
+
+// MockHealthMockRecorder is the mock recorder for MockHealth.
+type MockHealthMockRecorder struct {
+	mock *MockHealth
+}
+
+// NewMockHealth creates a new mock instance.
+func NewMockHealth(ctrl *gomock.Controller) *MockHealth {
+	mock := &MockHealth{ctrl: ctrl}
+	mock.recorder = &MockHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealth) EXPECT() *MockHealthMockRecorder {
+	return m.recorder
+}
+
+// Ready mocks base method.
+func (m *MockHealth) Ready(ctx context.Context) *models.Readiness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(*models.Readiness)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockHealthMockRecorder) Ready(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDelivery)(nil).Get), ctx, dimensions)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockHealth)(nil).Ready), ctx)
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+type PacingService struct {
+	campaign stores.Campaign
+	pacing   stores.Pacing
+}
+
+func NewPacing(campaignStore stores.Campaign, pacingStore stores.Pacing) PacingService {
+	return PacingService{campaign: campaignStore, pacing: pacingStore}
+}
+
+// Get returns the pacing of every campaign with an impression goal, whatever its status, or of the one campaign.
+func (s PacingService) Get(ctx context.Context, campaignID string) ([]models.Pacing, error) {
+	campaignID = strings.ToLower(strings.TrimSpace(campaignID))
+
+	var campaigns []models.Campaign
+	if campaignID == "" {
+		var err error
+		if campaigns, err = s.campaign.ListCampaigns(ctx, ""); err != nil {
+			return nil, err
+		}
+	} else {
+		campaign, err := s.campaign.GetCampaign(ctx, campaignID)
+		if err != nil {
+			return nil, err
+		}
+
+		if campaign.ImpressionGoal == nil {
+			return nil, invalidParam("Campaign " + campaignID + " has no impression goal")
+		}
+
+		campaigns = []models.Campaign{*campaign}
+	}
+
+	return s.pacing.Pacing(ctx, campaigns, time.Now())
+}
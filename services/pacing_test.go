@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func TestPacingService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCampaign := stores.NewMockCampaign(ctrl)
+	mockPacing := stores.NewMockPacing(ctrl)
+	ctx := context.Background()
+
+	service := NewPacing(mockCampaign, mockPacing)
+
+	spotify := models.Campaign{CampaignID: "spotify", ImpressionGoal: &models.ImpressionGoal{Total: 1000, Daily: 100}}
+	zepto := models.Campaign{CampaignID: "zepto"}
+	spotifyPacing := []models.Pacing{{CampaignID: "spotify", TotalGoal: 1000, DailyGoal: 100, DeliveredTotal: 1000,
+		State: "goal_reached"}}
+
+	tests := []struct {
+		name           string
+		campaignID     string
+		mockCalls      []interface{}
+		expectedResult []models.Pacing
+		expectedError  error
+	}{
+		{
+			name: "every campaign",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().ListCampaigns(ctx, "").Return([]models.Campaign{spotify, zepto}, nil),
+				mockPacing.EXPECT().Pacing(ctx, []models.Campaign{spotify, zepto}, gomock.Any()).Return(spotifyPacing, nil),
+			},
+			expectedResult: spotifyPacing,
+		},
+		{
+			name:       "one campaign",
+			campaignID: " Spotify ",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(&spotify, nil),
+				mockPacing.EXPECT().Pacing(ctx, []models.Campaign{spotify}, gomock.Any()).Return(spotifyPacing, nil),
+			},
+			expectedResult: spotifyPacing,
+		},
+		{
+			name:       "campaign without goal",
+			campaignID: "zepto",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "zepto").Return(&zepto, nil),
+			},
+			expectedError: invalidParam("Campaign zepto has no impression goal"),
+		},
+		{
+			name:       "unknown campaign",
+			campaignID: "unknown",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "unknown").Return(nil, &helpers.Error{StatusCode: http.StatusNotFound}),
+			},
+			expectedError: &helpers.Error{StatusCode: http.StatusNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Get(ctx, tt.campaignID)
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+type RuleService struct {
+	stores.Rule
+	campaign stores.Campaign
+}
+
+func NewRule(ruleStore stores.Rule, campaignStore stores.Campaign) RuleService {
+	return RuleService{Rule: ruleStore, campaign: campaignStore}
+}
+
+func (s RuleService) Get(ctx context.Context, campaignID string) (*models.TargetingRule, error) {
+	return s.Rule.GetRules(ctx, strings.ToLower(strings.TrimSpace(campaignID)))
+}
+
+// Save replaces every targeting rule of the campaign and its expression. Dimensions left out of the list are
+// unrestricted, and an empty expression always holds.
+func (s RuleService) Save(ctx context.Context, campaignID string, rules []models.Rule,
+	expression string) (*models.TargetingRule, error) {
+	campaignID = strings.ToLower(strings.TrimSpace(campaignID))
+
+	helpers.NormalizeRules(rules)
+
+	if err := helpers.ValidateRules(rules); err != nil {
+		return nil, err
+	}
+
+	expression, err := helpers.NormalizeExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.campaign.GetCampaign(ctx, campaignID); err != nil {
+		return nil, err
+	}
+
+	targetingRule := &models.TargetingRule{CampaignID: campaignID, Rules: rules, Expression: expression}
+	if err := s.Rule.SaveRules(ctx, targetingRule); err != nil {
+		return nil, err
+	}
+
+	return targetingRule, nil
+}
+
+func (s RuleService) Delete(ctx context.Context, campaignID string) error {
+	return s.Rule.DeleteRules(ctx, strings.ToLower(strings.TrimSpace(campaignID)))
+}
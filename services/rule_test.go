@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+	"github.com/Durga-Chikkala/delivery-service/stores"
+)
+
+func TestRuleService_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRule := stores.NewMockRule(ctrl)
+	mockCampaign := stores.NewMockCampaign(ctrl)
+	ctx := context.Background()
+
+	service := NewRule(mockRule, mockCampaign)
+
+	tests := []struct {
+		name           string
+		campaignID     string
+		rules          []models.Rule
+		expression     string
+		mockCalls      []interface{}
+		expectedResult *models.TargetingRule
+		expectedError  error
+	}{
+		{
+			name:       "rules are lower cased, countries canonical and saved",
+			campaignID: "Spotify",
+			rules: []models.Rule{
+				{Dimension: "Country", Include: []string{"US", " Canada ", "usa", ""}},
+				{Dimension: "os", Exclude: []string{"Web"}},
+			},
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(&models.Campaign{CampaignID: "spotify"}, nil),
+				mockRule.EXPECT().SaveRules(ctx, &models.TargetingRule{CampaignID: "spotify", Rules: []models.Rule{
+					{Dimension: "country", Include: []string{"us", "ca"}, Exclude: []string{}},
+					{Dimension: "os", Include: []string{}, Exclude: []string{"web"}},
+				}}).Return(nil),
+			},
+			expectedResult: &models.TargetingRule{CampaignID: "spotify", Rules: []models.Rule{
+				{Dimension: "country", Include: []string{"us", "ca"}, Exclude: []string{}},
+				{Dimension: "os", Include: []string{}, Exclude: []string{"web"}},
+			}},
+		},
+		{
+			name:          "unknown dimension",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "city", Include: []string{"paris"}}},
+			expectedError: invalidParam("Unknown dimension 'city', must be one of app, country, os, app_version, os_version, device, lang, daypart"),
+		},
+		{
+			name:       "duplicate dimension",
+			campaignID: "spotify",
+			rules: []models.Rule{
+				{Dimension: "os", Include: []string{"ios"}},
+				{Dimension: "OS", Include: []string{"android"}},
+			},
+			expectedError: invalidParam("Dimension 'os' is defined more than once"),
+		},
+		{
+			name:          "overlapping include and exclude",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "os", Include: []string{"iOS"}, Exclude: []string{"ios"}}},
+			expectedError: invalidParam("Value 'ios' of dimension 'os' is both included and excluded"),
+		},
+		{
+			name:       "daypart windows are lower cased",
+			campaignID: "spotify",
+			rules:      []models.Rule{{Dimension: "daypart", Windows: []models.TimeWindow{{Days: []string{"Fri", "SAT"}, Start: 22, End: 2}}}},
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(&models.Campaign{CampaignID: "spotify"}, nil),
+				mockRule.EXPECT().SaveRules(ctx, gomock.Any()).Return(nil),
+			},
+			expectedResult: &models.TargetingRule{CampaignID: "spotify", Rules: []models.Rule{{Dimension: "daypart",
+				Include: []string{}, Exclude: []string{}, Windows: []models.TimeWindow{{Days: []string{"fri", "sat"}, Start: 22, End: 2}}}}},
+		},
+		{
+			name:          "unknown device",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "device", Include: []string{"Phone", "watch"}}},
+			expectedError: invalidParam("Value 'watch' of dimension 'device' must be one of phone, tablet, tv"),
+		},
+		{
+			name:          "invalid version range",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "os_version", Include: []string{">=12", "~twelve"}}},
+			expectedError: invalidParam("Value '~twelve' of dimension 'os_version' must be a version or a range like >=5.2.0, <6 or ~1.4: invalid version twelve"),
+		},
+		{
+			name:       "patterns are validated and regular expressions keep their case",
+			campaignID: "spotify",
+			rules:      []models.Rule{{Dimension: "app", Include: []string{"COM.Google.*", `/com\.\S+/`}}},
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(&models.Campaign{CampaignID: "spotify"}, nil),
+				mockRule.EXPECT().SaveRules(ctx, gomock.Any()).Return(nil),
+			},
+			expectedResult: &models.TargetingRule{CampaignID: "spotify", Rules: []models.Rule{{Dimension: "app",
+				Include: []string{"com.google.*", `/com\.\S+/`}, Exclude: []string{}}}},
+		},
+		{
+			name:          "invalid regular expression",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "app", Exclude: []string{"/com.(google/"}}},
+			expectedError: invalidParam("Value '/com.(google/' of dimension 'app' is an invalid pattern: error parsing regexp: missing closing ): `(?i)^(?:com.(google)$`"),
+		},
+		{
+			name:          "daypart with values",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "daypart", Include: []string{"mon"}}},
+			expectedError: invalidParam("Dimension 'daypart' takes windows, not include or exclude values"),
+		},
+		{
+			name:          "windows on another dimension",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "os", Windows: []models.TimeWindow{{Start: 9, End: 17}}}},
+			expectedError: invalidParam("Only dimension 'daypart' takes windows"),
+		},
+		{
+			name:          "unknown day",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "daypart", Windows: []models.TimeWindow{{Days: []string{"monday"}, Start: 9, End: 17}}}},
+			expectedError: invalidParam("Unknown day 'monday', must be one of sun, mon, tue, wed, thu, fri, sat"),
+		},
+		{
+			name:          "invalid hours",
+			campaignID:    "spotify",
+			rules:         []models.Rule{{Dimension: "daypart", Windows: []models.TimeWindow{{Start: 9, End: 25}}}},
+			expectedError: invalidParam("Window start must be an hour from 0 to 23 and end a different hour from 1 to 24"),
+		},
+		{
+			name:       "expression is saved in its canonical form",
+			campaignID: "spotify",
+			rules:      []models.Rule{{Dimension: "os", Include: []string{"ios", "android"}}},
+			expression: "(Country = US AND os = iOS) OR country IN (ca,'Mexico') and not app_version < 2",
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "spotify").Return(&models.Campaign{CampaignID: "spotify"}, nil),
+				mockRule.EXPECT().SaveRules(ctx, gomock.Any()).Return(nil),
+			},
+			expectedResult: &models.TargetingRule{CampaignID: "spotify",
+				Rules:      []models.Rule{{Dimension: "os", Include: []string{"ios", "android"}, Exclude: []string{}}},
+				Expression: "(country = us and os = ios) or (country in (ca, mx) and not app_version < 2)"},
+		},
+		{
+			name:          "incomplete expression",
+			campaignID:    "spotify",
+			expression:    "country = us and",
+			expectedError: invalidParam("Parameter expression is invalid: expected a dimension at the end"),
+		},
+		{
+			name:          "comparison of a dimension without versions",
+			campaignID:    "spotify",
+			expression:    "country > us",
+			expectedError: invalidParam("Parameter expression is invalid: only app_version and os_version compare with >"),
+		},
+		{
+			name:          "expression values are validated like rule values",
+			campaignID:    "spotify",
+			expression:    "device in (phone, watch)",
+			expectedError: invalidParam("Value 'watch' of dimension 'device' must be one of phone, tablet, tv"),
+		},
+		{
+			name:       "campaign does not exist",
+			campaignID: "unknown",
+			rules:      []models.Rule{{Dimension: "os", Include: []string{"ios"}}},
+			mockCalls: []interface{}{
+				mockCampaign.EXPECT().GetCampaign(ctx, "unknown").Return(nil, &helpers.Error{StatusCode: http.StatusNotFound}),
+			},
+			expectedError: &helpers.Error{StatusCode: http.StatusNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Save(ctx, tt.campaignID, tt.rules, tt.expression)
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
@@ -1,28 +1,154 @@
 package services
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
 	"github.com/Durga-Chikkala/delivery-service/models"
 	"github.com/Durga-Chikkala/delivery-service/stores"
 )
 
 type Service struct {
 	stores.Delivery
+	geo     stores.Geo
+	beacons *helpers.BeaconSigner
 }
 
-func New(store stores.Delivery) Service {
-	return Service{Delivery: store}
+// New takes a nil signer when beacon URLs are disabled.
+func New(store stores.Delivery, geo stores.Geo, beacons *helpers.BeaconSigner) Service {
+	return Service{Delivery: store, geo: geo, beacons: beacons}
 }
 
-func (s Service) Get(ctx *gin.Context, dimensions *models.Dimension) (*[]models.Response, error) {
+// Get returns the campaigns delivered to the request, ranked, the first limit of them when limit isn't 0. The
+// delivered campaigns count towards their impression goals and the frequency caps of the user, and carry their beacon
+// URLs.
+func (s Service) Get(ctx *gin.Context, dimensions *models.Dimension, limit int) (*[]models.Response, error) {
 	convertDimensionsToLowerCase(dimensions)
-	return s.Delivery.Get(ctx, dimensions)
+
+	if err := s.resolveCountry(dimensions); err != nil {
+		return nil, err
+	}
+
+	if err := validateDimensions(dimensions); err != nil {
+		return nil, err
+	}
+
+	if err := setLocalTime(dimensions, time.Now()); err != nil {
+		return nil, err
+	}
+
+	campaigns, err := s.Delivery.Get(ctx, dimensions)
+	if err != nil || campaigns == nil {
+		return campaigns, err
+	}
+
+	delivered := s.Delivery.ReserveDeliveries(ctx, dimensions.UserID, *campaigns, limit)
+	if len(delivered) == 0 {
+		return nil, nil
+	}
+
+	campaigns = &delivered
+
+	if err := s.signBeacons(*campaigns, dimensions); err != nil {
+		return nil, err
+	}
+
+	return campaigns, nil
+}
+
+// signBeacons sets the impression, click and dismiss URLs of the campaigns, each with a token of its own.
+func (s Service) signBeacons(campaigns []models.Response, dimensions *models.Dimension) error {
+	if s.beacons == nil {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range campaigns {
+		campaign := &campaigns[i]
+		for _, beacon := range []struct {
+			eventType string
+			url       *string
+		}{
+			{constants.EventImpression, &campaign.ImpressionURL},
+			{constants.EventClick, &campaign.ClickURL},
+			{constants.EventDismiss, &campaign.DismissURL},
+		} {
+			beaconURL, err := s.beacons.URL(beacon.eventType, campaign.CampaignID, dimensions, now)
+			if err != nil {
+				return &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError,
+					Reason: "Failed to sign the beacon URLs: " + err.Error()}
+			}
+
+			*beacon.url = beaconURL
+		}
+	}
+
+	return nil
+}
+
+func (s Service) Explain(ctx context.Context, dimensions *models.Dimension, campaignID string) ([]models.Explanation, error) {
+	convertDimensionsToLowerCase(dimensions)
+
+	if err := s.resolveCountry(dimensions); err != nil {
+		return nil, err
+	}
+
+	if err := validateDimensions(dimensions); err != nil {
+		return nil, err
+	}
+
+	if err := setLocalTime(dimensions, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.Delivery.Explain(ctx, dimensions, strings.ToLower(strings.TrimSpace(campaignID)))
+}
+
+// resolveCountry resolves the country of a request without the country param from its client IP, and makes it
+// canonical like the countries of the rules so that every way of writing it shares the cached responses.
+func (s Service) resolveCountry(dimensions *models.Dimension) error {
+	dimensions.Country = helpers.CanonicalCountry(s.geo.ResolveCountry(dimensions.Country, dimensions.ClientIP))
+	if dimensions.Country == "" {
+		return invalidParam("Parameter country is required, it couldn't be resolved from the client IP")
+	}
+
+	return nil
+}
+
+func validateDimensions(dimensions *models.Dimension) error {
+	if dimensions.Device != "" && !slices.Contains(constants.Devices, dimensions.Device) {
+		return invalidParam("Parameter device must be one of " + strings.Join(constants.Devices, ", "))
+	}
+
+	return nil
+}
+
+// setLocalTime sets the time daypart rules are evaluated against, in the tz of the request or else of its country.
+func setLocalTime(dimensions *models.Dimension, now time.Time) error {
+	location, err := helpers.Location(dimensions.TZ, dimensions.Country)
+	if err != nil {
+		return invalidParam("Parameter tz must be an IANA time zone, like Asia/Kolkata")
+	}
+
+	dimensions.LocalTime = now.In(location)
+
+	return nil
 }
 
 func convertDimensionsToLowerCase(dimensions *models.Dimension) {
 	dimensions.APPID = strings.ToLower(dimensions.APPID)
-	dimensions.Country = strings.ToLower(dimensions.Country)
+	dimensions.Country = strings.ToLower(strings.TrimSpace(dimensions.Country))
 	dimensions.OS = strings.ToLower(dimensions.OS)
+	dimensions.AppVersion = strings.ToLower(strings.TrimSpace(dimensions.AppVersion))
+	dimensions.OSVersion = strings.ToLower(strings.TrimSpace(dimensions.OSVersion))
+	dimensions.Device = strings.ToLower(strings.TrimSpace(dimensions.Device))
+	dimensions.Lang = strings.ToLower(strings.TrimSpace(dimensions.Lang))
+	dimensions.TZ = strings.TrimSpace(dimensions.TZ)
 }
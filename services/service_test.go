@@ -1,12 +1,19 @@
 package services
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/Durga-Chikkala/delivery-service/helpers"
 	"github.com/Durga-Chikkala/delivery-service/models"
@@ -20,13 +27,15 @@ func TestService_Get(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := stores.NewMockDelivery(ctrl)
+	mockGeo := stores.NewMockGeo(ctrl)
 	ctx := &gin.Context{}
 
-	service := New(mockStore)
+	service := New(mockStore, mockGeo, nil)
 
 	tests := []struct {
 		name           string
 		dimensions     *models.Dimension
+		limit          int
 		mockCalls      []interface{}
 		expectedResult *[]models.Response
 		expectedError  error
@@ -39,11 +48,10 @@ func TestService_Get(t *testing.T) {
 				OS:      "Android",
 			},
 			mockCalls: []interface{}{
-				mockStore.EXPECT().Get(ctx, &models.Dimension{
-					APPID:   "com.app.test",
-					Country: "us",
-					OS:      "android",
-				}).Return(&[]models.Response{{CampaignID: "Campaign 1"}}, nil),
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+					"America/New_York"}).Return(&[]models.Response{{CampaignID: "Campaign 1"}}, nil),
+				mockStore.EXPECT().ReserveDeliveries(ctx, "", []models.Response{{CampaignID: "Campaign 1"}}, 0).
+					Return([]models.Response{{CampaignID: "Campaign 1"}}),
 			},
 			expectedResult: &[]models.Response{{CampaignID: "Campaign 1"}},
 			expectedError:  nil,
@@ -56,11 +64,8 @@ func TestService_Get(t *testing.T) {
 				OS:      "android",
 			},
 			mockCalls: []interface{}{
-				mockStore.EXPECT().Get(ctx, &models.Dimension{
-					APPID:   "com.app.test",
-					Country: "us",
-					OS:      "android",
-				}).Return(&[]models.Response{}, &helpers.Error{StatusCode: http.StatusInternalServerError}),
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+					"America/New_York"}).Return(&[]models.Response{}, &helpers.Error{StatusCode: http.StatusInternalServerError}),
 			},
 			expectedResult: &[]models.Response{},
 			expectedError:  &helpers.Error{StatusCode: http.StatusInternalServerError},
@@ -73,24 +78,213 @@ func TestService_Get(t *testing.T) {
 				OS:      "Android",
 			},
 			mockCalls: []interface{}{
-				mockStore.EXPECT().Get(ctx, &models.Dimension{
-					APPID:   "com.app.test",
-					Country: "us",
-					OS:      "android",
-				}).Return(nil, nil),
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+					"America/New_York"}).Return(nil, nil),
 			},
 			expectedResult: nil,
 			expectedError:  nil,
 		},
+		{
+			name:       "tz overrides the time zone of the country",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android", TZ: " Asia/Kolkata "},
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android",
+					TZ: "Asia/Kolkata"}, "Asia/Kolkata"}).Return(nil, nil),
+			},
+		},
+		{
+			name:          "unknown tz",
+			dimensions:    &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android", TZ: "Mars/Olympus"},
+			expectedError: invalidParam("Parameter tz must be an IANA time zone, like Asia/Kolkata"),
+		},
+		{
+			name: "optional dimensions are lower cased",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android", AppVersion: " 5.2.0 ",
+				OSVersion: "12", Device: "Tablet", Lang: "EN"},
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android",
+					AppVersion: "5.2.0", OSVersion: "12", Device: "tablet", Lang: "en"}, "America/New_York"}).Return(nil, nil),
+			},
+		},
+		{
+			name:          "unknown device",
+			dimensions:    &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android", Device: "watch"},
+			expectedError: invalidParam("Parameter device must be one of phone, tablet, tv"),
+		},
+		{
+			name:       "country is resolved from the client IP",
+			dimensions: &models.Dimension{APPID: "com.app.test", OS: "android", ClientIP: "203.0.113.7"},
+			mockCalls: []interface{}{
+				mockGeo.EXPECT().ResolveCountry("", "203.0.113.7").Return("in"),
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "in",
+					OS: "android", ClientIP: "203.0.113.7"}, "Asia/Kolkata"}).Return(nil, nil),
+			},
+		},
+		{
+			name:       "country can't be resolved",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: " ", OS: "android", ClientIP: "10.0.0.1"},
+			mockCalls: []interface{}{
+				mockGeo.EXPECT().ResolveCountry("", "10.0.0.1").Return(""),
+			},
+			expectedError: invalidParam("Parameter country is required, it couldn't be resolved from the client IP"),
+		},
+		{
+			name:       "limit keeps the first campaigns",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+			limit:      1,
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+					"America/New_York"}).Return(&[]models.Response{{CampaignID: "spotify"}, {CampaignID: "zepto"}}, nil),
+				mockStore.EXPECT().ReserveDeliveries(ctx, "", []models.Response{{CampaignID: "spotify"}, {CampaignID: "zepto"}}, 1).
+					Return([]models.Response{{CampaignID: "spotify"}}),
+			},
+			expectedResult: &[]models.Response{{CampaignID: "spotify"}},
+		},
+		{
+			name:       "limit above the number of campaigns",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+			limit:      3,
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+					"America/New_York"}).Return(&[]models.Response{{CampaignID: "spotify"}, {CampaignID: "zepto"}}, nil),
+				mockStore.EXPECT().ReserveDeliveries(ctx, "", []models.Response{{CampaignID: "spotify"}, {CampaignID: "zepto"}}, 3).
+					Return([]models.Response{{CampaignID: "spotify"}, {CampaignID: "zepto"}}),
+			},
+			expectedResult: &[]models.Response{{CampaignID: "spotify"}, {CampaignID: "zepto"}},
+		},
+		{
+			name:       "campaigns the user is capped for make room for the next ones",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android", UserID: "device-1"},
+			limit:      1,
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android",
+					UserID: "device-1"}, "America/New_York"}).Return(&[]models.Response{{CampaignID: "spotify"},
+					{CampaignID: "zepto"}}, nil),
+				mockStore.EXPECT().ReserveDeliveries(ctx, "device-1", []models.Response{{CampaignID: "spotify"},
+					{CampaignID: "zepto"}}, 1).Return([]models.Response{{CampaignID: "zepto"}}),
+			},
+			expectedResult: &[]models.Response{{CampaignID: "zepto"}},
+		},
+		{
+			name:       "every campaign is capped for the user",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android", UserID: "device-1"},
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android",
+					UserID: "device-1"}, "America/New_York"}).Return(&[]models.Response{{CampaignID: "spotify"}}, nil),
+				mockStore.EXPECT().ReserveDeliveries(ctx, "device-1", []models.Response{{CampaignID: "spotify"}}, 0).
+					Return([]models.Response{}),
+			},
+		},
+		{
+			name:       "country names are canonical",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "United Kingdom", OS: "android"},
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "gb",
+					OS: "android"}, "Europe/London"}).Return(nil, nil),
+			},
+		},
+		{
+			name:       "unknown country is in UTC",
+			dimensions: &models.Dimension{APPID: "com.app.test", Country: "atlantis", OS: "android"},
+			mockCalls: []interface{}{
+				mockStore.EXPECT().Get(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "atlantis",
+					OS: "android"}, "UTC"}).Return(nil, nil),
+			},
+		},
 	}
 
+	// The country param is kept as it is.
+	mockGeo.EXPECT().ResolveCountry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(country, _ string) string { return country }).AnyTimes()
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			result, err := service.Get(ctx, tt.dimensions)
+			result, err := service.Get(ctx, tt.dimensions, tt.limit)
 
 			assert.Equal(t, tt.expectedResult, result)
 			assert.Equal(t, tt.expectedError, err)
 		})
 	}
 }
+
+func TestService_GetBeacons(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := stores.NewMockDelivery(ctrl)
+	mockGeo := stores.NewMockGeo(ctrl)
+	ctx := &gin.Context{}
+
+	beacons := helpers.NewBeaconSigner([]models.BeaconKey{{ID: "k1", Secret: []byte(strings.Repeat("s", 32))}},
+		time.Hour, "https://delivery.example.com")
+	service := New(mockStore, mockGeo, beacons)
+
+	mockGeo.EXPECT().ResolveCountry("us", "").Return("us")
+	mockStore.EXPECT().Get(ctx, gomock.Any()).Return(&[]models.Response{{CampaignID: "spotify"}}, nil)
+	mockStore.EXPECT().ReserveDeliveries(ctx, "", gomock.Any(), 0).Return([]models.Response{{CampaignID: "spotify"}})
+
+	result, err := service.Get(ctx, &models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"}, 0)
+	require.NoError(t, err)
+
+	campaign := (*result)[0]
+	for eventType, beacon := range map[string]string{"impression": campaign.ImpressionURL, "click": campaign.ClickURL,
+		"dismiss": campaign.DismissURL} {
+		beaconURL, err := url.Parse(beacon)
+		require.NoError(t, err)
+		assert.Equal(t, "delivery.example.com", beaconURL.Host)
+		assert.Equal(t, helpers.BeaconPath, beaconURL.Path)
+
+		claims, err := beacons.Verify(beaconURL.Query().Get("token"), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, eventType, claims.Type)
+		assert.Equal(t, "spotify", claims.CampaignID)
+		assert.Equal(t, models.EventDimensions{App: "com.app.test", Country: "us", OS: "android"}, claims.Dimensions)
+	}
+}
+
+func TestService_Explain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := stores.NewMockDelivery(ctrl)
+	mockGeo := stores.NewMockGeo(ctrl)
+	ctx := context.Background()
+
+	service := New(mockStore, mockGeo, nil)
+
+	mockGeo.EXPECT().ResolveCountry("us", "").Return("us")
+
+	mockStore.EXPECT().Explain(ctx, localDimensions{models.Dimension{APPID: "com.app.test", Country: "us", OS: "android"},
+		"America/New_York"}, "spotify").
+		Return([]models.Explanation{{CampaignID: "spotify"}}, nil)
+
+	result, err := service.Explain(ctx, &models.Dimension{APPID: "com.app.test", Country: "US", OS: "Android"}, " Spotify")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []models.Explanation{{CampaignID: "spotify"}}, result)
+}
+
+// localDimensions matches the dimensions once their local time, which must be in the location, is left out.
+type localDimensions struct {
+	expected models.Dimension
+	location string
+}
+
+func (m localDimensions) Matches(x interface{}) bool {
+	dimensions, ok := x.(*models.Dimension)
+	if !ok || dimensions.LocalTime.IsZero() || dimensions.LocalTime.Location().String() != m.location {
+		return false
+	}
+
+	actual := *dimensions
+	actual.LocalTime = time.Time{}
+
+	return reflect.DeepEqual(m.expected, actual)
+}
+
+func (m localDimensions) String() string {
+	return fmt.Sprintf("%+v with local time in %s", m.expected, m.location)
+}
@@ -0,0 +1,83 @@
+package stores
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// noncePruneInterval is how often the expired nonces are forgotten without Redis.
+const noncePruneInterval = time.Minute
+
+// nonceSet remembers the used nonces until their token expires, when there is no Redis to share them with the other
+// instances.
+type nonceSet struct {
+	mu        sync.Mutex
+	used      map[string]time.Time
+	nextPrune time.Time
+}
+
+func newNonceSet() *nonceSet {
+	return &nonceSet{used: make(map[string]time.Time)}
+}
+
+func (n *nonceSet) claim(nonce string, until, now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if now.After(n.nextPrune) {
+		for used, expiry := range n.used {
+			if !now.Before(expiry) {
+				delete(n.used, used)
+			}
+		}
+
+		n.nextPrune = now.Add(noncePruneInterval)
+	}
+
+	if expiry, ok := n.used[nonce]; ok && now.Before(expiry) {
+		return false
+	}
+
+	n.used[nonce] = until
+
+	return true
+}
+
+func (n *nonceSet) release(nonce string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.used, nonce)
+}
+
+// ClaimNonce marks the nonce of a beacon token as used until the token expires, and tells whether it was unused.
+// Redis shares the nonces between the instances, they are kept in memory without it.
+func (s *Store) ClaimNonce(ctx context.Context, nonce string, until time.Time) (bool, error) {
+	if s.redisClient == nil {
+		return s.nonces.claim(nonce, until, time.Now()), nil
+	}
+
+	claimed, err := s.redisClient.SetNX(ctx, "beacon:"+nonce, 1, time.Until(until)).Result()
+	if err != nil {
+		s.logger.Error("Error while Claiming beacon nonce", "Error", err.Error())
+		return false, unavailableError("Redis", err)
+	}
+
+	return claimed, nil
+}
+
+// ReleaseNonce forgets the claim of the nonce, its token being accepted again.
+func (s *Store) ReleaseNonce(ctx context.Context, nonce string) error {
+	if s.redisClient == nil {
+		s.nonces.release(nonce)
+		return nil
+	}
+
+	if err := s.redisClient.Del(ctx, "beacon:"+nonce).Err(); err != nil {
+		s.logger.Error("Error while Releasing beacon nonce", "Error", err.Error())
+		return unavailableError("Redis", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package stores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceSet(t *testing.T) {
+	nonces := newNonceSet()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, nonces.claim("a", now.Add(time.Hour), now))
+	assert.False(t, nonces.claim("a", now.Add(time.Hour), now.Add(time.Minute)))
+	assert.True(t, nonces.claim("b", now.Add(time.Minute), now))
+
+	// Expired nonces are forgotten, their tokens are rejected as expired anyway.
+	later := now.Add(2 * time.Hour)
+	assert.True(t, nonces.claim("c", later.Add(time.Hour), later))
+	assert.Len(t, nonces.used, 1)
+
+	// A released nonce can be claimed again.
+	nonces.release("c")
+	assert.True(t, nonces.claim("c", later.Add(time.Hour), later))
+}
@@ -0,0 +1,70 @@
+package stores
+
+import "math/bits"
+
+// bitset is a fixed size set of campaign positions in the targeting index.
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+func (b bitset) clear(i int) {
+	b[i/64] &^= 1 << (uint(i) % 64)
+}
+
+func (b bitset) clone() bitset {
+	c := make(bitset, len(b))
+	copy(c, b)
+
+	return c
+}
+
+// or adds the members of o, a nil o being empty.
+func (b bitset) or(o bitset) {
+	for i := range o {
+		b[i] |= o[i]
+	}
+}
+
+// and keeps only the members of o, a nil o being empty.
+func (b bitset) and(o bitset) {
+	for i := range b {
+		if i < len(o) {
+			b[i] &= o[i]
+		} else {
+			b[i] = 0
+		}
+	}
+}
+
+// andNot removes the members of o, a nil o being empty.
+func (b bitset) andNot(o bitset) {
+	for i := range o {
+		b[i] &^= o[i]
+	}
+}
+
+func (b bitset) isEmpty() bool {
+	for _, word := range b {
+		if word != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// each calls fn with every member in ascending order.
+func (b bitset) each(fn func(i int)) {
+	for i, word := range b {
+		for word != 0 {
+			fn(i*64 + bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+func (s *Store) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	if err := s.Repository.CreateCampaign(ctx, campaign); err != nil {
+		return err
+	}
+
+	s.campaignsChanged(ctx, campaign.CampaignID)
+
+	return nil
+}
+
+func (s *Store) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	if err := s.Repository.UpdateCampaign(ctx, campaign); err != nil {
+		return err
+	}
+
+	s.campaignsChanged(ctx, campaign.CampaignID)
+
+	return nil
+}
+
+func (s *Store) DeleteCampaign(ctx context.Context, campaignID string) error {
+	if err := s.Repository.DeleteCampaign(ctx, campaignID); err != nil {
+		return err
+	}
+
+	s.campaignsChanged(ctx, campaignID)
+
+	return nil
+}
+
+// CampaignExists looks the campaign up in the targeting index once it is loaded, and in the repository otherwise.
+func (s *Store) CampaignExists(ctx context.Context, campaignID string) (bool, error) {
+	if snapshot := s.index.load(); snapshot != nil {
+		return snapshot.stored[campaignID], nil
+	}
+
+	_, err := s.GetCampaign(ctx, campaignID)
+
+	var notFound *helpers.Error
+	if errors.As(err, &notFound) && notFound.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	return err == nil, err
+}
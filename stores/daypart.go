@@ -0,0 +1,80 @@
+package stores
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// evaluateDaypart matches when the campaign has no daypart rule with windows or when the local time is in one of them.
+func evaluateDaypart(rules []models.Rule, local time.Time) models.DimensionVerdict {
+	verdict := models.DimensionVerdict{Dimension: constants.Daypart, Value: helpers.Daypart(local),
+		Verdict: constants.VerdictNoRule, Matched: true}
+
+	windows := daypartWindows(rules)
+	if len(windows) == 0 {
+		return verdict
+	}
+
+	verdict.Verdict = constants.VerdictInWindow
+	if !inWindows(windows, local) {
+		verdict.Verdict = constants.VerdictOutsideWindow
+		verdict.Matched = false
+	}
+
+	return verdict
+}
+
+func daypartWindows(rules []models.Rule) []models.TimeWindow {
+	var windows []models.TimeWindow
+	for _, rule := range rules {
+		if rule.Dimension == constants.Daypart {
+			windows = append(windows, rule.Windows...)
+		}
+	}
+
+	return windows
+}
+
+func inWindows(windows []models.TimeWindow, local time.Time) bool {
+	day := constants.Weekdays[local.Weekday()]
+	previousDay := constants.Weekdays[(local.Weekday()+6)%7]
+	hour := local.Hour()
+
+	for _, window := range windows {
+		onDay := len(window.Days) == 0 || slices.Contains(window.Days, day)
+
+		if window.Start < window.End {
+			if onDay && hour >= window.Start && hour < window.End {
+				return true
+			}
+
+			continue
+		}
+
+		// The window runs past midnight, its early hours belong to the day it started on.
+		onPreviousDay := len(window.Days) == 0 || slices.Contains(window.Days, previousDay)
+		if (onDay && hour >= window.Start) || (onPreviousDay && hour < window.End) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// localTime is the time daypart rules are evaluated against, now in UTC when the request has no local time.
+func localTime(dimensions *models.Dimension, now time.Time) time.Time {
+	if dimensions.LocalTime.IsZero() {
+		return now.UTC()
+	}
+
+	return dimensions.LocalTime
+}
+
+// nextDaypart returns the start of the next local hour, when responses depending on daypart rules may change.
+func nextDaypart(local time.Time) time.Time {
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour()+1, 0, 0, 0, local.Location())
+}
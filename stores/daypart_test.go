@@ -0,0 +1,88 @@
+package stores
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+func TestInWindows(t *testing.T) {
+	// 2026-03-06 is a Friday.
+	friday := func(hour int) time.Time { return time.Date(2026, 3, 6, hour, 30, 0, 0, time.UTC) }
+
+	weekdayEvenings := []models.TimeWindow{{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: 18, End: 22}}
+	fridayNight := []models.TimeWindow{{Days: []string{"fri"}, Start: 22, End: 2}}
+
+	tests := []struct {
+		name     string
+		windows  []models.TimeWindow
+		local    time.Time
+		expected bool
+	}{
+		{name: "within the hours", windows: weekdayEvenings, local: friday(18), expected: true},
+		{name: "end is excluded", windows: weekdayEvenings, local: friday(22), expected: false},
+		{name: "other day", windows: weekdayEvenings, local: friday(19).AddDate(0, 0, 1), expected: false},
+		{name: "every day", windows: []models.TimeWindow{{Start: 0, End: 24}}, local: friday(23), expected: true},
+		{name: "past midnight on the day", windows: fridayNight, local: friday(23), expected: true},
+		{name: "past midnight on the next day", windows: fridayNight, local: friday(1).AddDate(0, 0, 1), expected: true},
+		{name: "early hours of the day itself", windows: fridayNight, local: friday(1), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, inWindows(tt.windows, tt.local))
+		})
+	}
+}
+
+func TestIndex_MatchDaypart(t *testing.T) {
+	campaigns, rules := readTestdata(t)
+	for i := range rules {
+		if rules[i].CampaignID == "spotify" {
+			rules[i].Rules = append(rules[i].Rules, models.Rule{Dimension: "daypart",
+				Windows: []models.TimeWindow{{Days: []string{"fri"}, Start: 18, End: 22}}})
+		}
+	}
+
+	snapshot := buildIndex(campaigns, rules)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 13:00 UTC on a Friday is 18:30 in Kolkata.
+	now := time.Date(2026, 3, 6, 13, 0, 0, 0, time.UTC)
+	dimensions := &models.Dimension{APPID: "exampleapp", OS: "android", Country: "us"}
+
+	assert.Empty(t, snapshot.match(dimensions, now))
+
+	dimensions.LocalTime = now.In(kolkata)
+	assert.Equal(t, []string{"spotify"}, responseIDs(snapshot.match(dimensions, now)))
+	assert.Equal(t, time.Date(2026, 3, 6, 19, 0, 0, 0, kolkata), nextDaypart(dimensions.LocalTime))
+}
+
+func TestLoadRules_Daypart(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.csv"),
+		[]byte("CampaignID,Dimension,Include,Exclude\nspotify,daypart,\"sat,sun 10-14|fri-mon 22-2\",\n"), 0o600))
+
+	rules, err := loadRules(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TargetingRule{{CampaignID: "spotify", Rules: []models.Rule{{Dimension: "daypart",
+		Include: []string{}, Exclude: []string{}, Windows: []models.TimeWindow{
+			{Days: []string{"sat", "sun"}, Start: 10, End: 14},
+			{Days: []string{"fri", "sat", "sun", "mon"}, Start: 22, End: 2},
+		}}}}}, rules)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.csv"),
+		[]byte("CampaignID,Dimension,Include,Exclude\nspotify,daypart,evenings,\n"), 0o600))
+
+	_, err = loadRules(dir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,262 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// finalFlushTimeout bounds the flush of the buffered events on shutdown.
+const finalFlushTimeout = 10 * time.Second
+
+// EventBuffer holds the accepted events in memory and writes them to the sink in batches, when a batch is full or
+// every flush interval. A batch the sink fails to write is kept for the next flush, as long as it fits.
+type EventBuffer struct {
+	sink          EventSink
+	logger        *slog.Logger
+	counter       *prometheus.CounterVec
+	dropped       prometheus.Counter
+	batchSize     int
+	capacity      int
+	flushInterval time.Duration
+
+	mu     sync.Mutex
+	events []models.Event
+	full   chan struct{}
+}
+
+func NewEventBuffer(sink EventSink, logger *slog.Logger, counter *prometheus.CounterVec, dropped prometheus.Counter,
+	batchSize, capacity int, flushInterval time.Duration) *EventBuffer {
+	return &EventBuffer{sink: sink, logger: logger, counter: counter, dropped: dropped, batchSize: batchSize,
+		capacity: capacity, flushInterval: flushInterval, full: make(chan struct{}, 1)}
+}
+
+// NewEventSink returns the sink of the configured EVENT_SINK.
+func NewEventSink(helper *models.Helpers) (EventSink, error) {
+	switch helper.EventSink {
+	case constants.EventSinkMongo:
+		if helper.DB == nil {
+			return nil, errors.New("MongoDB is not configured")
+		}
+
+		return NewMongoEventSink(helper.DB), nil
+	case constants.EventSinkFile:
+		return NewFileEventSink(helper.EventFilePath)
+	default:
+		return nil, errors.New("unknown event sink " + helper.EventSink)
+	}
+}
+
+// AddEvents accepts all the events or none of them, when the buffer can't hold them until the next flush.
+func (b *EventBuffer) AddEvents(events []models.Event) error {
+	b.mu.Lock()
+	if len(b.events)+len(events) > b.capacity {
+		b.mu.Unlock()
+		return &helpers.Error{Code: "Service Unavailable", StatusCode: http.StatusServiceUnavailable,
+			Reason: "Too many events waiting to be written, retry later"}
+	}
+
+	b.events = append(b.events, events...)
+	full := len(b.events) >= b.batchSize
+	b.mu.Unlock()
+
+	for i := range events {
+		b.counter.WithLabelValues(events[i].CampaignID, events[i].Type).Inc()
+	}
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Run flushes the events until the context is done, and the remaining ones before returning.
+func (b *EventBuffer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), finalFlushTimeout)
+			b.flush(flushCtx)
+			cancel()
+
+			if pending := b.pending(); pending > 0 {
+				b.logger.Error("Events dropped on shutdown", "Count", pending)
+				b.dropped.Add(float64(pending))
+			}
+
+			return
+		case <-ticker.C:
+			b.flush(ctx)
+		case <-b.full:
+			b.flush(ctx)
+		}
+	}
+}
+
+// flush writes the buffered events batch by batch, and stops at the first batch the sink fails to write. Only the
+// events of the batch the sink didn't write are kept, the others would be written twice.
+func (b *EventBuffer) flush(ctx context.Context) {
+	for {
+		b.mu.Lock()
+		size := min(len(b.events), b.batchSize)
+		batch := b.events[:size:size]
+		b.events = b.events[size:]
+		b.mu.Unlock()
+
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := b.sink.WriteEvents(ctx, batch); err != nil {
+			var partial *partialWriteError
+			if errors.As(err, &partial) {
+				batch = partial.failed
+			}
+
+			b.logger.Error("Error while Writing events, retrying on the next flush", "Count", len(batch),
+				"Error", err.Error())
+			b.requeue(batch)
+
+			return
+		}
+	}
+}
+
+// requeue puts the batch back in front of the events accepted meanwhile, dropping its oldest events when they don't
+// fit anymore.
+func (b *EventBuffer) requeue(batch []models.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if overflow := len(batch) + len(b.events) - b.capacity; overflow > 0 {
+		overflow = min(overflow, len(batch))
+		b.logger.Error("Events dropped, the buffer is full", "Count", overflow)
+		b.dropped.Add(float64(overflow))
+		batch = batch[overflow:]
+	}
+
+	b.events = append(batch, b.events...)
+}
+
+func (b *EventBuffer) pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.events)
+}
+
+// partialWriteError is returned by a sink that wrote only some events of a batch, failed holding the others.
+type partialWriteError struct {
+	failed []models.Event
+	err    error
+}
+
+func (e *partialWriteError) Error() string {
+	return e.err.Error()
+}
+
+func (e *partialWriteError) Unwrap() error {
+	return e.err
+}
+
+// MongoEventSink inserts the events in the events collection.
+type MongoEventSink struct {
+	collection *mongo.Collection
+}
+
+func NewMongoEventSink(db *mongo.Database) *MongoEventSink {
+	return &MongoEventSink{collection: db.Collection("events")}
+}
+
+// WriteEvents inserts the events unordered, an event failing doesn't stop the others.
+func (s *MongoEventSink) WriteEvents(ctx context.Context, events []models.Event) error {
+	documents := make([]interface{}, len(events))
+	for i := range events {
+		documents[i] = events[i]
+	}
+
+	_, err := s.collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+
+	return failedInserts(events, err)
+}
+
+// failedInserts narrows the error of an unordered insertion to the events the server reported as failed. Without
+// a report of every failure, like on a network or write concern error, all the events are failed.
+func failedInserts(events []models.Event, err error) error {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return err
+	}
+
+	failed := make([]models.Event, 0, len(bulkErr.WriteErrors))
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Index < 0 || writeErr.Index >= len(events) {
+			return err
+		}
+
+		failed = append(failed, events[writeErr.Index])
+	}
+
+	return &partialWriteError{failed: failed, err: err}
+}
+
+// FileEventSink appends the events to a file a day in the directory, one JSON document a line, like
+// events-2026-03-01.ndjson for the events received on that day in UTC.
+type FileEventSink struct {
+	dir string
+}
+
+func NewFileEventSink(dir string) (*FileEventSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileEventSink{dir: dir}, nil
+}
+
+func (s *FileEventSink) WriteEvents(_ context.Context, events []models.Event) error {
+	var data []byte
+	for i := range events {
+		line, err := json.Marshal(events[i])
+		if err != nil {
+			return err
+		}
+
+		data = append(append(data, line...), '\n')
+	}
+
+	name := "events-" + time.Now().UTC().Format(time.DateOnly) + "." + constants.FormatNDJSON
+
+	file, err := os.OpenFile(filepath.Join(s.dir, name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,189 @@
+package stores
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// recordingSink keeps the batches it is given, and fails while err is set.
+type recordingSink struct {
+	mu      sync.Mutex
+	batches [][]models.Event
+	err     error
+}
+
+func (s *recordingSink) WriteEvents(_ context.Context, events []models.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.batches = append(s.batches, events)
+
+	return nil
+}
+
+func newTestEventBuffer(sink EventSink, batchSize, capacity int) *EventBuffer {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "campaign_events"}, []string{"campaign_id", "type"})
+	dropped := prometheus.NewCounter(prometheus.CounterOpts{Name: "events_dropped"})
+
+	return NewEventBuffer(sink, helpers.InitializeLogger(), counter, dropped, batchSize, capacity, time.Hour)
+}
+
+func testEvents(campaignID string, count int) []models.Event {
+	events := make([]models.Event, count)
+	for i := range events {
+		events[i] = models.Event{Type: "impression", CampaignID: campaignID}
+	}
+
+	return events
+}
+
+func TestEventBuffer_Flush(t *testing.T) {
+	sink := &recordingSink{}
+	buffer := newTestEventBuffer(sink, 2, 4)
+
+	require.NoError(t, buffer.AddEvents(testEvents("spotify", 3)))
+	assert.Equal(t, 3.0, testutil.ToFloat64(buffer.counter.WithLabelValues("spotify", "impression")))
+
+	err := buffer.AddEvents(testEvents("spotify", 2))
+	assert.Equal(t, 503, err.(*helpers.Error).StatusCode)
+
+	buffer.flush(context.Background())
+
+	assert.Equal(t, [][]models.Event{testEvents("spotify", 2), testEvents("spotify", 1)}, sink.batches)
+	assert.Equal(t, 0, buffer.pending())
+}
+
+func TestEventBuffer_FlushFailure(t *testing.T) {
+	sink := &recordingSink{err: errors.New("sink is down")}
+	buffer := newTestEventBuffer(sink, 2, 3)
+
+	require.NoError(t, buffer.AddEvents(testEvents("spotify", 2)))
+	buffer.flush(context.Background())
+
+	assert.Equal(t, 2, buffer.pending())
+
+	// The failed batch no longer fits along with the events accepted meanwhile, its oldest event is dropped.
+	buffer.mu.Lock()
+	batch := buffer.events
+	buffer.events = nil
+	buffer.mu.Unlock()
+
+	require.NoError(t, buffer.AddEvents(testEvents("zepto", 2)))
+	buffer.requeue(batch)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(buffer.dropped))
+	assert.Equal(t, append(testEvents("spotify", 1), testEvents("zepto", 2)...), buffer.events)
+
+	sink.err = nil
+	buffer.flush(context.Background())
+
+	assert.Equal(t, [][]models.Event{append(testEvents("spotify", 1), testEvents("zepto", 1)...), testEvents("zepto", 1)},
+		sink.batches)
+}
+
+func TestEventBuffer_PartialFlushFailure(t *testing.T) {
+	events := []models.Event{{Type: "impression", CampaignID: "spotify"}, {Type: "click", CampaignID: "spotify"},
+		{Type: "impression", CampaignID: "zepto"}}
+	sink := &recordingSink{err: &partialWriteError{failed: events[1:2], err: errors.New("write failed")}}
+	buffer := newTestEventBuffer(sink, 3, 10)
+
+	require.NoError(t, buffer.AddEvents(events))
+	buffer.flush(context.Background())
+
+	// Only the event the sink didn't write is retried.
+	assert.Equal(t, events[1:2], buffer.events)
+
+	sink.err = nil
+	buffer.flush(context.Background())
+
+	assert.Equal(t, [][]models.Event{events[1:2]}, sink.batches)
+}
+
+func TestFailedInserts(t *testing.T) {
+	events := append(testEvents("spotify", 2), testEvents("zepto", 2)...)
+	networkErr := errors.New("connection reset")
+
+	partial := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 1, Code: 121}}, {WriteError: mongo.WriteError{Index: 3, Code: 121}}}}
+	unacknowledged := mongo.BulkWriteException{WriteConcernError: &mongo.WriteConcernError{Code: 64},
+		WriteErrors: partial.WriteErrors}
+
+	assert.Nil(t, failedInserts(events, nil))
+	assert.Equal(t, networkErr, failedInserts(events, networkErr))
+	assert.Equal(t, unacknowledged, failedInserts(events, unacknowledged))
+	assert.Equal(t, &partialWriteError{failed: []models.Event{events[1], events[3]}, err: partial},
+		failedInserts(events, partial))
+}
+
+func TestEventBuffer_Run(t *testing.T) {
+	sink := &recordingSink{}
+	buffer := newTestEventBuffer(sink, 2, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		buffer.Run(ctx)
+		close(done)
+	}()
+
+	// A full batch is flushed right away, the rest on shutdown.
+	require.NoError(t, buffer.AddEvents(testEvents("spotify", 2)))
+	assert.Eventually(t, func() bool { return buffer.pending() == 0 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, buffer.AddEvents(testEvents("zepto", 1)))
+	cancel()
+	<-done
+
+	assert.Equal(t, [][]models.Event{testEvents("spotify", 2), testEvents("zepto", 1)}, sink.batches)
+}
+
+func TestFileEventSink(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "events")
+
+	sink, err := NewFileEventSink(dir)
+	require.NoError(t, err)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []models.Event{
+		{Type: "impression", CampaignID: "spotify", Dimensions: models.EventDimensions{App: "com.spotify", Country: "in"},
+			Timestamp: at, ReceivedAt: at},
+		{Type: "click", CampaignID: "spotify", Timestamp: at, ReceivedAt: at},
+	}
+
+	require.NoError(t, sink.WriteEvents(context.Background(), events[:1]))
+	require.NoError(t, sink.WriteEvents(context.Background(), events[1:]))
+
+	file, err := os.Open(filepath.Join(dir, "events-"+time.Now().UTC().Format(time.DateOnly)+".ndjson"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	var written []models.Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event models.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		written = append(written, event)
+	}
+
+	assert.Equal(t, events, written)
+}
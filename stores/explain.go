@@ -0,0 +1,88 @@
+package stores
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// Explain evaluates the targeting rules of every campaign, or only of the given one, against the dimensions and
+// tells why each campaign is delivered or not.
+func (s *Store) Explain(ctx context.Context, dimensions *models.Dimension, campaignID string) ([]models.Explanation, error) {
+	campaigns, err := s.ListCampaigns(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByCampaign := make(map[string]*models.TargetingRule, len(rules))
+	for i := range rules {
+		rulesByCampaign[rules[i].CampaignID] = &rules[i]
+	}
+
+	now := time.Now()
+	explanations := make([]models.Explanation, 0, len(campaigns))
+	for i := range campaigns {
+		if campaignID != "" && campaigns[i].CampaignID != campaignID {
+			continue
+		}
+
+		explanations = append(explanations, explain(&campaigns[i], rulesByCampaign[campaigns[i].CampaignID], dimensions, now))
+	}
+
+	return explanations, nil
+}
+
+// explain tells why the campaign is delivered or not, rule being nil when the campaign has no targeting rules. The
+// expression is explained after the dimensions.
+func explain(campaign *models.Campaign, rule *models.TargetingRule, dimensions *models.Dimension,
+	now time.Time) models.Explanation {
+	explanation := models.Explanation{CampaignID: campaign.CampaignID, Status: campaign.Status,
+		Dimensions: make([]models.DimensionVerdict, 0, len(constants.RuleDimensions)+1)}
+
+	if rule == nil {
+		explanation.Reason = "campaign has no targeting rules"
+		return explanation
+	}
+
+	verdicts := make([]models.DimensionVerdict, 0, len(constants.RuleDimensions)+1)
+	for _, dimension := range constants.Dimensions {
+		verdicts = append(verdicts, evaluateDimension(rule.Rules, dimension, dimensionValue(dimensions, dimension), nil))
+	}
+
+	verdicts = append(verdicts, evaluateDaypart(rule.Rules, localTime(dimensions, now)),
+		evaluateExpression(rule.Expression, dimensions))
+
+	var unmatched []string
+	for _, verdict := range verdicts {
+		explanation.Dimensions = append(explanation.Dimensions, verdict)
+
+		if !verdict.Matched {
+			unmatched = append(unmatched, verdict.Dimension+" is "+verdict.Verdict)
+		}
+	}
+
+	switch {
+	case len(unmatched) > 0:
+		explanation.Reason = strings.Join(unmatched, ", ")
+	case campaign.Status != constants.StatusActive:
+		explanation.Reason = "campaign is " + campaign.Status
+	case campaign.StartAt != nil && now.Before(*campaign.StartAt):
+		explanation.Reason = "campaign starts at " + helpers.FormatScheduleTime(campaign.StartAt, campaign.Timezone)
+	case campaign.EndAt != nil && !now.Before(*campaign.EndAt):
+		explanation.Reason = "campaign ended at " + helpers.FormatScheduleTime(campaign.EndAt, campaign.Timezone)
+	default:
+		explanation.Delivered = true
+		explanation.Reason = "delivered"
+	}
+
+	return explanation
+}
@@ -0,0 +1,413 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// FileRepository holds the campaigns and rules in memory, loaded from the campaigns and rules files of a directory.
+// Writes are kept in memory only, the files are never written back.
+type FileRepository struct {
+	mu        sync.RWMutex
+	campaigns map[string]models.Campaign
+	rules     map[string]models.TargetingRule
+}
+
+// NewFileRepository loads campaigns.csv or campaigns.json and rules.csv or rules.json from the directory. The CSV
+// files have the columns of the import, the JSON files hold an array like the export.
+func NewFileRepository(dir string) (*FileRepository, error) {
+	repo := &FileRepository{campaigns: make(map[string]models.Campaign), rules: make(map[string]models.TargetingRule)}
+
+	campaigns, err := loadCampaigns(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, campaign := range campaigns {
+		repo.campaigns[campaign.CampaignID] = campaign
+	}
+
+	rules, err := loadRules(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		repo.rules[rule.CampaignID] = rule
+	}
+
+	return repo, nil
+}
+
+// loadCampaigns reads campaigns.json, or else campaigns.csv with the parser of the import, and validates the
+// campaigns like the import does. Any invalid campaign fails the load.
+func loadCampaigns(dir string) ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+
+	path := filepath.Join(dir, constants.EntityCampaigns+".json")
+
+	found, err := readJSONFile(path, &campaigns)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		for i := range campaigns {
+			helpers.NormalizeCampaign(&campaigns[i])
+
+			if err := helpers.ValidateCampaign(&campaigns[i]); err != nil {
+				return nil, fmt.Errorf("%s: campaign %s: %w", filepath.Base(path), campaigns[i].CampaignID, err)
+			}
+		}
+
+		return campaigns, nil
+	}
+
+	path = filepath.Join(dir, constants.EntityCampaigns+".csv")
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	parsed, err := helpers.ReadCampaignsCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	if len(parsed.Errors) > 0 {
+		return nil, rowError(filepath.Base(path), parsed.Errors[0])
+	}
+
+	for _, row := range parsed.Campaigns {
+		campaigns = append(campaigns, *row.Campaign)
+	}
+
+	return campaigns, nil
+}
+
+// loadRules reads rules.json, or else rules.csv with the parser of the import, grouped by campaign in the order the
+// campaigns first appear, and validates the rules like the import does. Any invalid rule fails the load.
+func loadRules(dir string) ([]models.TargetingRule, error) {
+	var rules []models.TargetingRule
+
+	path := filepath.Join(dir, constants.EntityRules+".json")
+
+	found, err := readJSONFile(path, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		for i := range rules {
+			if err := normalizeRules(&rules[i]); err != nil {
+				return nil, fmt.Errorf("%s: campaign %s: %w", filepath.Base(path), rules[i].CampaignID, err)
+			}
+		}
+
+		return rules, nil
+	}
+
+	path = filepath.Join(dir, constants.EntityRules+".csv")
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	parsed, err := helpers.ReadRulesCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	if len(parsed.Errors) > 0 {
+		return nil, rowError(filepath.Base(path), parsed.Errors[0])
+	}
+
+	for _, row := range parsed.Rules {
+		rules = append(rules, *row.Rule)
+	}
+
+	return rules, nil
+}
+
+// normalizeRules normalizes and validates the rules and expression of a campaign of rules.json.
+func normalizeRules(rule *models.TargetingRule) error {
+	rule.CampaignID = strings.ToLower(strings.TrimSpace(rule.CampaignID))
+	helpers.NormalizeRules(rule.Rules)
+
+	if err := helpers.ValidateRules(rule.Rules); err != nil {
+		return err
+	}
+
+	expression, err := helpers.NormalizeExpression(rule.Expression)
+	rule.Expression = expression
+
+	return err
+}
+
+// rowError reports the first invalid row of a file.
+func rowError(file string, rowErr models.ImportError) error {
+	if rowErr.CampaignID == "" {
+		return fmt.Errorf("%s: row %d: %s", file, rowErr.Row, rowErr.Reason)
+	}
+
+	return fmt.Errorf("%s: row %d: campaign %s: %s", file, rowErr.Row, rowErr.CampaignID, rowErr.Reason)
+}
+
+// readJSONFile tells whether the file exists, a missing file isn't an error.
+func readJSONFile(path string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return true, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+func (r *FileRepository) Backend() string {
+	return constants.BackendFile
+}
+
+// Ping always succeeds, the data is in memory.
+func (r *FileRepository) Ping(context.Context) error {
+	return nil
+}
+
+func (r *FileRepository) MatchRules(_ context.Context, dimensions *models.Dimension) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	local := localTime(dimensions, time.Now())
+
+	var campaignIDs []string
+	for campaignID, rule := range r.rules {
+		if matchRules(rule.Rules, rule.Expression, dimensions, local) {
+			campaignIDs = append(campaignIDs, campaignID)
+		}
+	}
+
+	sort.Strings(campaignIDs)
+
+	return campaignIDs, nil
+}
+
+// FindActiveCampaignsByIDs returns the active campaigns whose schedule includes the current time.
+func (r *FileRepository) FindActiveCampaignsByIDs(_ context.Context, campaignIDs []string) (*[]models.Response, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+
+	var campaigns []models.Response
+	for _, campaignID := range campaignIDs {
+		campaign, ok := r.campaigns[campaignID]
+		if ok && campaign.Status == constants.StatusActive && inSchedule(campaign.StartAt, campaign.EndAt, now) {
+			campaigns = append(campaigns, response(campaign))
+		}
+	}
+
+	return &campaigns, nil
+}
+
+func (r *FileRepository) NextScheduleBoundary(_ context.Context, campaignIDs []string, now time.Time) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var campaigns []models.Campaign
+	for _, campaignID := range campaignIDs {
+		if campaign, ok := r.campaigns[campaignID]; ok && campaign.Status == constants.StatusActive {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+
+	return nextBoundary(campaigns, now), nil
+}
+
+func (r *FileRepository) CreateCampaign(_ context.Context, campaign *models.Campaign) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.campaigns[campaign.CampaignID]; ok {
+		return &helpers.Error{Code: "Entity Already Exists", StatusCode: http.StatusConflict,
+			Reason: "Campaign " + campaign.CampaignID + " already exists"}
+	}
+
+	r.campaigns[campaign.CampaignID] = *campaign
+
+	return nil
+}
+
+func (r *FileRepository) GetCampaign(_ context.Context, campaignID string) (*models.Campaign, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	campaign, ok := r.campaigns[campaignID]
+	if !ok {
+		return nil, campaignNotFound(campaignID)
+	}
+
+	return &campaign, nil
+}
+
+func (r *FileRepository) ListCampaigns(_ context.Context, status string) ([]models.Campaign, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	campaigns := make([]models.Campaign, 0, len(r.campaigns))
+	for _, campaign := range r.campaigns {
+		if status == "" || campaign.Status == status {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+
+	sort.Slice(campaigns, func(i, j int) bool { return campaigns[i].CampaignID < campaigns[j].CampaignID })
+
+	return campaigns, nil
+}
+
+func (r *FileRepository) UpdateCampaign(_ context.Context, campaign *models.Campaign) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.campaigns[campaign.CampaignID]; !ok {
+		return campaignNotFound(campaign.CampaignID)
+	}
+
+	r.campaigns[campaign.CampaignID] = *campaign
+
+	return nil
+}
+
+// DeleteCampaign removes the campaign along with its targeting rules, as rules of a deleted campaign can never match.
+func (r *FileRepository) DeleteCampaign(_ context.Context, campaignID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.campaigns[campaignID]; !ok {
+		return campaignNotFound(campaignID)
+	}
+
+	delete(r.campaigns, campaignID)
+	delete(r.rules, campaignID)
+
+	return nil
+}
+
+func (r *FileRepository) GetRules(_ context.Context, campaignID string) (*models.TargetingRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[campaignID]
+	if !ok {
+		return nil, rulesNotFound(campaignID)
+	}
+
+	rule = cloneTargetingRule(rule)
+
+	return &rule, nil
+}
+
+func (r *FileRepository) SaveRules(_ context.Context, rule *models.TargetingRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.CampaignID] = cloneTargetingRule(*rule)
+
+	return nil
+}
+
+func (r *FileRepository) DeleteRules(_ context.Context, campaignID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[campaignID]; !ok {
+		return rulesNotFound(campaignID)
+	}
+
+	delete(r.rules, campaignID)
+
+	return nil
+}
+
+func (r *FileRepository) ListRules(_ context.Context) ([]models.TargetingRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]models.TargetingRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, cloneTargetingRule(rule))
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].CampaignID < rules[j].CampaignID })
+
+	return rules, nil
+}
+
+func (r *FileRepository) ImportCampaign(_ context.Context, campaign *models.Campaign, rule *models.TargetingRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if campaign != nil {
+		r.campaigns[campaign.CampaignID] = *campaign
+	}
+
+	if rule != nil {
+		r.rules[rule.CampaignID] = cloneTargetingRule(*rule)
+	}
+
+	return nil
+}
+
+// cloneTargetingRule copies the value lists, so that callers can't change the stored rules through them.
+func cloneTargetingRule(rule models.TargetingRule) models.TargetingRule {
+	rules := make([]models.Rule, 0, len(rule.Rules))
+	for _, r := range rule.Rules {
+		windows := make([]models.TimeWindow, 0, len(r.Windows))
+		for _, window := range r.Windows {
+			windows = append(windows, models.TimeWindow{Days: slices.Clone(window.Days), Start: window.Start, End: window.End})
+		}
+
+		if len(windows) == 0 {
+			windows = nil
+		}
+
+		rules = append(rules, models.Rule{Dimension: r.Dimension, Include: slices.Clone(r.Include),
+			Exclude: slices.Clone(r.Exclude), Windows: windows})
+	}
+
+	return models.TargetingRule{CampaignID: rule.CampaignID, Rules: rules, Expression: rule.Expression}
+}
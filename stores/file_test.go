@@ -0,0 +1,551 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// assertMatchesIndex checks that the repository matches the same campaigns as the targeting index for every
+// combination of the testdata values.
+func assertMatchesIndex(t *testing.T, repo Repository) {
+	t.Helper()
+	ctx := context.Background()
+
+	campaigns, rules := readTestdata(t)
+	snapshot := buildIndex(campaigns, rules)
+
+	values := map[string][]string{"app": {"unknown"}, "os": {"unknown"}, "country": {"unknown"}}
+	for _, rule := range rules {
+		for _, r := range rule.Rules {
+			values[r.Dimension] = append(values[r.Dimension], r.Include...)
+			values[r.Dimension] = append(values[r.Dimension], r.Exclude...)
+		}
+	}
+
+	for _, app := range values["app"] {
+		for _, os := range values["os"] {
+			for _, country := range values["country"] {
+				dimensions := &models.Dimension{APPID: app, OS: os, Country: country}
+
+				campaignIDs, err := repo.MatchRules(ctx, dimensions)
+				require.NoError(t, err)
+
+				var delivered []models.Response
+				if len(campaignIDs) > 0 {
+					active, err := repo.FindActiveCampaignsByIDs(ctx, campaignIDs)
+					require.NoError(t, err)
+					delivered = *active
+				}
+
+				assert.ElementsMatch(t, snapshot.match(dimensions, time.Now()), delivered, "dimensions %+v", *dimensions)
+			}
+		}
+	}
+}
+
+func TestFileRepository_MatchRules(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	assertMatchesIndex(t, repo)
+}
+
+func TestLoadRules_Expression(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.csv"), []byte("CampaignID,Dimension,Include,Exclude\n"+
+		"spotify,os,ios,\nspotify,expression,\"country in (us, ca) or device = tablet\",\n"), 0o600))
+
+	rules, err := loadRules(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TargetingRule{{CampaignID: "spotify", Rules: []models.Rule{{Dimension: "os",
+		Include: []string{"ios"}, Exclude: []string{}}}, Expression: "country in (us, ca) or device = tablet"}}, rules)
+}
+
+func TestLoadCampaigns_Ranking(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight\n"+
+			"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE,,,,10,\n"), 0o600))
+
+	campaigns, err := loadCampaigns(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Campaign{{CampaignID: "spotify", Name: "Spotify", Image: "https://example.com/spotify.png",
+		CTA: "Listen Now", Status: "ACTIVE", Priority: 10}}, campaigns)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight\n"+
+			"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE,,,,high,\n"), 0o600))
+
+	_, err = loadCampaigns(dir)
+	assert.EqualError(t, err, "campaigns.csv: row 2: campaign spotify: Parameter priority must be an integer")
+}
+
+func TestLoadCampaigns_FrequencyCap(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap\n"+
+			"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE,,,,,,3/24h\n"), 0o600))
+
+	campaigns, err := loadCampaigns(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Campaign{{CampaignID: "spotify", Name: "Spotify", Image: "https://example.com/spotify.png",
+		CTA: "Listen Now", Status: "ACTIVE", FrequencyCap: &models.FrequencyCap{Impressions: 3, Window: "24h"}}}, campaigns)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap\n"+
+			"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE,,,,,,daily\n"), 0o600))
+
+	_, err = loadCampaigns(dir)
+	assert.EqualError(t, err, "campaigns.csv: row 2: campaign spotify: Parameter frequency_cap is invalid: "+
+		"invalid frequency cap daily, expected impressions/window like 3/24h")
+}
+
+func TestLoadCampaigns_ImpressionGoal(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap,TotalGoal,DailyGoal\n"+
+			"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE,,,,,,,100000,\n"+
+			"zepto,Zepto,https://example.com/zepto.png,Order Now,ACTIVE,,,,,,,,5000\n"), 0o600))
+
+	campaigns, err := loadCampaigns(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Campaign{
+		{CampaignID: "spotify", Name: "Spotify", Image: "https://example.com/spotify.png", CTA: "Listen Now", Status: "ACTIVE",
+			ImpressionGoal: &models.ImpressionGoal{Total: 100000}},
+		{CampaignID: "zepto", Name: "Zepto", Image: "https://example.com/zepto.png", CTA: "Order Now", Status: "ACTIVE",
+			ImpressionGoal: &models.ImpressionGoal{Daily: 5000}},
+	}, campaigns)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"CampaignID,Name,Image,CTA,Status,StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap,TotalGoal,DailyGoal\n"+
+			"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE,,,,,,,lots,\n"), 0o600))
+
+	_, err = loadCampaigns(dir)
+	assert.EqualError(t, err, "campaigns.csv: row 2: campaign spotify: Parameter impression_goal is invalid: "+
+		"invalid impression goal lots, expected a number of impressions")
+}
+
+func TestLoadCampaigns_Header(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"status,CampaignID,Priority,Name,CTA,Image\n"+
+			"active,Spotify,3,Spotify,Listen Now,https://example.com/spotify.png\n"), 0o600))
+
+	campaigns, err := loadCampaigns(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Campaign{{CampaignID: "spotify", Name: "Spotify", Image: "https://example.com/spotify.png",
+		CTA: "Listen Now", Status: "ACTIVE", Priority: 3}}, campaigns)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"CampaignID,Name,Image,CTA,Status,StartAt\n"+
+			"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE,tomorrow\n"), 0o600))
+
+	_, err = loadCampaigns(dir)
+	assert.EqualError(t, err, "campaigns.csv: row 2: campaign spotify: Parameter start_at is invalid: "+
+		"invalid time tomorrow, expected RFC3339 or 2006-01-02 15:04:05")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.csv"), []byte(
+		"spotify,Spotify,https://example.com/spotify.png,Listen Now,ACTIVE\n"), 0o600))
+
+	_, err = loadCampaigns(dir)
+	assert.EqualError(t, err, "campaigns.csv: The campaigns file must start with a header of the columns "+
+		"CampaignID,Name,Image,CTA,Status and optionally StartAt,EndAt,Timezone,Priority,Weight,FrequencyCap,TotalGoal,DailyGoal")
+}
+
+func TestLoadRules_Validation(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.csv"), []byte("Include,Exclude,Dimension,CampaignID\n"+
+		"US|Canada,,Country,Spotify\n"), 0o600))
+
+	rules, err := loadRules(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TargetingRule{{CampaignID: "spotify", Rules: []models.Rule{{Dimension: "country",
+		Include: []string{"us", "ca"}, Exclude: []string{}}}}}, rules)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.csv"), []byte("CampaignID,Dimension,Include,Exclude\n"+
+		"spotify,os,ios,\nspotify,city,paris,\n"), 0o600))
+
+	_, err = loadRules(dir)
+	assert.EqualError(t, err, "rules.csv: row 3: campaign spotify: Unknown dimension 'city', must be one of "+
+		"app, country, os, app_version, os_version, device, lang, daypart")
+}
+
+func TestNewFileRepository_JSON(t *testing.T) {
+	dir := t.TempDir()
+
+	campaigns := `[{"campaign_id":"spotify","name":"Spotify","image":"https://example.com/spotify.png",` +
+		`"cta":"Listen Now","status":"ACTIVE"}]`
+	rules := `[{"campaign_id":"spotify","rules":[{"dimension":"country","include":["us"],"exclude":[]}]}]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "campaigns.json"), []byte(campaigns), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.json"), []byte(rules), 0o600))
+
+	repo, err := NewFileRepository(dir)
+	require.NoError(t, err)
+
+	campaignIDs, err := repo.MatchRules(context.Background(), &models.Dimension{APPID: "app", OS: "ios", Country: "us"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spotify"}, campaignIDs)
+
+	campaignIDs, err = repo.MatchRules(context.Background(), &models.Dimension{APPID: "app", OS: "ios", Country: "in"})
+	require.NoError(t, err)
+	assert.Empty(t, campaignIDs)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.json"), []byte("{"), 0o600))
+
+	_, err = NewFileRepository(dir)
+	assert.Error(t, err)
+}
+
+func TestFileRepository_Campaigns(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	campaign := models.Campaign{CampaignID: "spotify", Name: "Spotify", Status: "INACTIVE"}
+
+	err = repo.CreateCampaign(ctx, &campaign)
+	assert.Equal(t, &helpers.Error{Code: "Entity Already Exists", StatusCode: http.StatusConflict,
+		Reason: "Campaign spotify already exists"}, err)
+
+	require.NoError(t, repo.UpdateCampaign(ctx, &campaign))
+
+	got, err := repo.GetCampaign(ctx, "spotify")
+	require.NoError(t, err)
+	assert.Equal(t, campaign, *got)
+
+	require.NoError(t, repo.DeleteCampaign(ctx, "spotify"))
+
+	_, err = repo.GetRules(ctx, "spotify")
+	assert.Equal(t, rulesNotFound("spotify"), err)
+
+	assert.Equal(t, campaignNotFound("spotify"), repo.UpdateCampaign(ctx, &campaign))
+	assert.Equal(t, campaignNotFound("spotify"), repo.DeleteCampaign(ctx, "spotify"))
+}
+
+func TestStore_GetWithoutRedis(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	dimensions := &models.Dimension{APPID: "com.whatsapp", OS: "ios", Country: "in"}
+	expected := []models.Response{
+		{CampaignID: "duolingo", Image: "https://example.com/images/duolingo.png", CTA: "Start Learning"},
+		{CampaignID: "whatsapp", Image: "https://example.com/images/whatsapp.png", CTA: "Send Message"},
+	}
+
+	campaigns, err := store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	assert.Equal(t, expected, *campaigns)
+
+	_, err = store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+
+	campaigns, err = store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	assert.Equal(t, expected, *campaigns)
+
+	require.NoError(t, store.DeleteRules(context.Background(), "whatsapp"))
+
+	campaigns, err = store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	assert.Equal(t, expected[:1], *campaigns)
+}
+
+func TestStore_WriteWithRedisDown(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	store := New(repo, redisClient, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	dimensions := &models.Dimension{APPID: "com.whatsapp", OS: "ios", Country: "in"}
+
+	campaigns, err := store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	assert.Len(t, *campaigns, 2)
+
+	_, err = store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteCampaign(context.Background(), "duolingo"))
+
+	campaigns, err = store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Response{{CampaignID: "whatsapp", Image: "https://example.com/images/whatsapp.png",
+		CTA: "Send Message"}}, *campaigns)
+
+	rules, err := store.GetRules(context.Background(), "whatsapp")
+	require.NoError(t, err)
+	require.NoError(t, store.SaveRules(context.Background(), rules))
+
+	// The cache of the written campaigns and the rules version are retried on every refresh until Redis is back.
+	_, err = store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"duolingo": true, "whatsapp": true}, store.index.pending)
+	assert.True(t, store.index.rulesChanged)
+
+	store.redisClient = nil
+	_, err = store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, store.index.pending)
+	assert.False(t, store.index.rulesChanged)
+}
+
+func TestStore_FrequencyCapWithRedisDown(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	store := New(repo, redisClient, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	duolingo, err := store.GetCampaign(context.Background(), "duolingo")
+	require.NoError(t, err)
+
+	duolingo.FrequencyCap = &models.FrequencyCap{Impressions: 1, Window: "24h"}
+	require.NoError(t, store.UpdateCampaign(context.Background(), duolingo))
+
+	_, err = store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+
+	dimensions := &models.Dimension{APPID: "com.whatsapp", OS: "ios", Country: "in", UserID: "device-1"}
+
+	// Impressions can be neither reserved nor counted, so the capped campaign is still delivered.
+	campaigns, err := store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	require.Len(t, *campaigns, 2)
+
+	for i := 0; i < 2; i++ {
+		assert.Len(t, store.ReserveDeliveries(context.Background(), dimensions.UserID, *campaigns, 0), 2)
+	}
+}
+
+func TestStore_ReserveDeliveriesWithoutRedis(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	campaigns := []models.Response{{CampaignID: "spotify", FrequencyCap: &models.FrequencyCap{Impressions: 1, Window: "1h"}},
+		{CampaignID: "zepto"}, {CampaignID: "tinder"}}
+
+	assert.Equal(t, campaigns[:2], store.ReserveDeliveries(context.Background(), "device-1", campaigns, 2))
+	assert.Equal(t, campaigns, store.ReserveDeliveries(context.Background(), "device-1", campaigns, 0))
+	assert.Equal(t, campaigns, store.ReserveDeliveries(context.Background(), "", campaigns, 5))
+}
+
+// countingRepository counts the loads of the targeting index, fails them with loadErr and fails the import of
+// failing.
+type countingRepository struct {
+	Repository
+	loads   atomic.Int32
+	loadErr error
+	failing string
+}
+
+func (r *countingRepository) ListCampaigns(ctx context.Context, status string) ([]models.Campaign, error) {
+	r.loads.Add(1)
+	if r.loadErr != nil {
+		return nil, r.loadErr
+	}
+
+	return r.Repository.ListCampaigns(ctx, status)
+}
+
+func (r *countingRepository) ImportCampaign(ctx context.Context, campaign *models.Campaign, rule *models.TargetingRule) error {
+	if campaign != nil && campaign.CampaignID == r.failing {
+		return errors.New("import failed")
+	}
+
+	return r.Repository.ImportCampaign(ctx, campaign, rule)
+}
+
+func TestStore_ImportCampaignsRefreshesOnce(t *testing.T) {
+	ctx := context.Background()
+	fileRepo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	repo := &countingRepository{Repository: fileRepo, failing: "failing"}
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	_, err = store.RefreshIndex(ctx)
+	require.NoError(t, err)
+
+	imports := make([]models.CampaignImport, 0, 10)
+	for i := 0; i < 10; i++ {
+		imports = append(imports, models.CampaignImport{Campaign: &models.Campaign{CampaignID: fmt.Sprintf("imported-%d", i),
+			Status: "ACTIVE"}})
+	}
+
+	require.NoError(t, store.ImportCampaigns(ctx, imports))
+	assert.Equal(t, int32(2), repo.loads.Load())
+
+	// The campaigns written before the failing one are indexed all the same.
+	err = store.ImportCampaigns(ctx, []models.CampaignImport{{Campaign: &models.Campaign{CampaignID: "written"}},
+		{Campaign: &models.Campaign{CampaignID: "failing"}}, {Campaign: &models.Campaign{CampaignID: "skipped"}}})
+	assert.EqualError(t, err, "import failed")
+	assert.Equal(t, int32(3), repo.loads.Load())
+
+	for campaignID, exists := range map[string]bool{"written": true, "failing": false, "skipped": false} {
+		found, err := store.CampaignExists(ctx, campaignID)
+		require.NoError(t, err)
+		assert.Equal(t, exists, found, campaignID)
+	}
+}
+
+func TestStore_WriteWithRefreshFailing(t *testing.T) {
+	ctx := context.Background()
+	fileRepo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	repo := &countingRepository{Repository: fileRepo}
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	_, err = store.RefreshIndex(ctx)
+	require.NoError(t, err)
+
+	// The writes are done, the index is left to the next refresh rather than failing them.
+	repo.loadErr = errors.New("store unavailable")
+	require.NoError(t, store.CreateCampaign(ctx, &models.Campaign{CampaignID: "spotify-2", Status: "ACTIVE"}))
+	require.NoError(t, store.SaveRules(ctx, &models.TargetingRule{CampaignID: "spotify-2"}))
+	require.NoError(t, store.DeleteCampaign(ctx, "duolingo"))
+
+	exists, err := store.CampaignExists(ctx, "duolingo")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	repo.loadErr = nil
+	_, err = store.RefreshIndex(ctx)
+	require.NoError(t, err)
+
+	exists, err = store.CampaignExists(ctx, "duolingo")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestStore_ConcurrentWritesRefreshIndex(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	_, err = store.RefreshIndex(ctx)
+	require.NoError(t, err)
+
+	duolingo, err := store.GetCampaign(ctx, "duolingo")
+	require.NoError(t, err)
+
+	// Whichever write lands last, the index ends up with it rather than with a snapshot loaded before it.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(status string) {
+			defer wg.Done()
+
+			campaign := *duolingo
+			campaign.Status = status
+			assert.NoError(t, store.UpdateCampaign(ctx, &campaign))
+		}([]string{"ACTIVE", "INACTIVE"}[i%2])
+	}
+
+	wg.Wait()
+
+	stored, err := store.GetCampaign(ctx, "duolingo")
+	require.NoError(t, err)
+
+	delivered := false
+	for _, campaign := range store.index.load().campaigns {
+		delivered = delivered || campaign.CampaignID == "duolingo"
+	}
+
+	assert.Equal(t, stored.Status == "ACTIVE", delivered)
+}
+
+func TestStore_CampaignExists(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	for _, indexed := range []bool{false, true} {
+		if indexed {
+			_, err = store.RefreshIndex(context.Background())
+			require.NoError(t, err)
+		}
+
+		exists, err := store.CampaignExists(context.Background(), "duolingo")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = store.CampaignExists(context.Background(), "unknown")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestCapWindow(t *testing.T) {
+	assert.Equal(t, time.Duration(0), capWindow(nil))
+	assert.Equal(t, time.Duration(0), capWindow(&models.FrequencyCap{Window: "24h"}))
+	assert.Equal(t, time.Duration(0), capWindow(&models.FrequencyCap{Impressions: 3, Window: "daily"}))
+	assert.Equal(t, 24*time.Hour, capWindow(&models.FrequencyCap{Impressions: 3, Window: "24h"}))
+}
+
+func TestStore_CheckDependencies(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+	statuses := store.CheckDependencies(context.Background())
+
+	assert.Equal(t, []models.DependencyStatus{{Name: "file", Status: "up", Required: true, LatencyMS: statuses[0].LatencyMS},
+		{Name: "redis", Status: "disabled"}}, statuses)
+	assert.False(t, store.IndexLoaded())
+
+	store = New(repo, redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1}), helpers.InitializeLogger(),
+		cacheHit, cacheMiss)
+	statuses = store.CheckDependencies(context.Background())
+
+	assert.Equal(t, "down", statuses[1].Status)
+	assert.NotEmpty(t, statuses[1].Error)
+
+	_, err = store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+	assert.True(t, store.IndexLoaded())
+}
@@ -0,0 +1,27 @@
+package stores
+
+import (
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// frequencyKey holds the impressions of the campaign seen by the user as a sorted set scored by their time in
+// milliseconds, so that the ones out of the window are trimmed and the others counted.
+func frequencyKey(campaignID, userID string) string {
+	return "frequency:" + campaignID + ":" + userID
+}
+
+// capWindow returns the window of the campaign cap, zero when the campaign isn't capped.
+func capWindow(frequencyCap *models.FrequencyCap) time.Duration {
+	if frequencyCap == nil || frequencyCap.Impressions < 1 {
+		return 0
+	}
+
+	window, err := time.ParseDuration(frequencyCap.Window)
+	if err != nil || window <= 0 {
+		return 0
+	}
+
+	return window
+}
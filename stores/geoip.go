@@ -0,0 +1,73 @@
+package stores
+
+import (
+	"log/slog"
+	"net"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+)
+
+// countryLookup is the part of the MaxMind reader the resolution uses.
+type countryLookup interface {
+	Lookup(ip net.IP, result any) error
+}
+
+// geoIPRecord reads the country of country and city databases.
+type geoIPRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// GeoIP resolves the country of the delivery requests without one from their client IP.
+type GeoIP struct {
+	reader  countryLookup
+	logger  *slog.Logger
+	sources *prometheus.CounterVec
+}
+
+// NewGeoIP resolves countries in the MaxMind database, a nil reader disabling the resolution.
+func NewGeoIP(reader *maxminddb.Reader, logger *slog.Logger, sources *prometheus.CounterVec) GeoIP {
+	geoIP := GeoIP{logger: logger, sources: sources}
+	if reader != nil {
+		geoIP.reader = reader
+	}
+
+	return geoIP
+}
+
+// ResolveCountry returns the country param when the request has one, or else the lower cased ISO code of the country
+// of the client IP, "" when it is unknown. The source of the country is logged and counted.
+func (g GeoIP) ResolveCountry(country, clientIP string) string {
+	source := constants.CountrySourceQuery
+	if country == "" {
+		source = constants.CountrySourceNone
+		if country = g.lookup(clientIP); country != "" {
+			source = constants.CountrySourceGeoIP
+		}
+	}
+
+	g.sources.WithLabelValues(source).Inc()
+	g.logger.Debug("Resolved country", "Source", source, "Country", country, "ClientIP", clientIP)
+
+	return country
+}
+
+func (g GeoIP) lookup(clientIP string) string {
+	ip := net.ParseIP(clientIP)
+	if g.reader == nil || ip == nil {
+		return ""
+	}
+
+	var record geoIPRecord
+	if err := g.reader.Lookup(ip, &record); err != nil {
+		g.logger.Warn("Error while Looking up client IP", "ClientIP", clientIP, "Error", err.Error())
+		return ""
+	}
+
+	return strings.ToLower(record.Country.ISOCode)
+}
@@ -0,0 +1,61 @@
+package stores
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+)
+
+// fakeCountries looks up the countries of fixed IPs like a MaxMind country database.
+type fakeCountries map[string]string
+
+func (f fakeCountries) Lookup(ip net.IP, result any) error {
+	isoCode, ok := f[ip.String()]
+	if !ok {
+		return errors.New("database is corrupt")
+	}
+
+	result.(*geoIPRecord).Country.ISOCode = isoCode
+
+	return nil
+}
+
+func TestGeoIP_ResolveCountry(t *testing.T) {
+	sources := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "country_sources"}, []string{"source"})
+	geoIP := GeoIP{reader: fakeCountries{"203.0.113.7": "IN", "2001:db8::1": "CA", "198.51.100.1": ""},
+		logger: helpers.InitializeLogger(), sources: sources}
+
+	tests := []struct {
+		name     string
+		country  string
+		clientIP string
+		expected string
+		source   string
+	}{
+		{name: "country param", country: "us", clientIP: "203.0.113.7", expected: "us", source: "query"},
+		{name: "ipv4", clientIP: "203.0.113.7", expected: "in", source: "geoip"},
+		{name: "ipv6", clientIP: "2001:db8::1", expected: "ca", source: "geoip"},
+		{name: "ip without country", clientIP: "198.51.100.1", expected: "", source: "none"},
+		{name: "lookup error", clientIP: "192.0.2.1", expected: "", source: "none"},
+		{name: "invalid ip", clientIP: "unknown", expected: "", source: "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counted := testutil.ToFloat64(sources.WithLabelValues(tt.source))
+
+			assert.Equal(t, tt.expected, geoIP.ResolveCountry(tt.country, tt.clientIP))
+			assert.Equal(t, counted+1, testutil.ToFloat64(sources.WithLabelValues(tt.source)))
+		})
+	}
+
+	disabled := NewGeoIP(nil, helpers.InitializeLogger(), sources)
+
+	assert.Equal(t, "", disabled.ResolveCountry("", "203.0.113.7"))
+}
@@ -0,0 +1,56 @@
+package stores
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// CheckDependencies pings the repository and Redis concurrently. The repository is required to serve, Redis isn't
+// as delivery skips the cache while it is down.
+func (s *Store) CheckDependencies(ctx context.Context) []models.DependencyStatus {
+	statuses := []models.DependencyStatus{
+		{Name: s.Backend(), Required: true},
+		{Name: constants.DependencyRedis},
+	}
+
+	pings := []func(ctx context.Context) error{s.Ping, nil}
+	if s.redisClient != nil {
+		pings[1] = func(ctx context.Context) error { return s.redisClient.Ping(ctx).Err() }
+	}
+
+	var wg sync.WaitGroup
+	for i := range statuses {
+		if pings[i] == nil {
+			statuses[i].Status = constants.DependencyDisabled
+			continue
+		}
+
+		wg.Add(1)
+		go func(status *models.DependencyStatus, ping func(ctx context.Context) error) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := ping(ctx)
+			status.LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+
+			status.Status = constants.DependencyUp
+			if err != nil {
+				status.Status = constants.DependencyDown
+				status.Error = err.Error()
+			}
+		}(&statuses[i], pings[i])
+	}
+
+	wg.Wait()
+
+	return statuses
+}
+
+// IndexLoaded tells whether the targeting index has been built once, delivery is served from the store before.
+func (s *Store) IndexLoaded() bool {
+	return s.index.load() != nil
+}
@@ -0,0 +1,46 @@
+package stores
+
+import (
+	"context"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// ImportCampaign writes the campaign and its rules atomically where the repository supports it, either of them can
+// be nil to leave it untouched.
+func (s *Store) ImportCampaign(ctx context.Context, campaign *models.Campaign, rule *models.TargetingRule) error {
+	return s.ImportCampaigns(ctx, []models.CampaignImport{{Campaign: campaign, Rule: rule}})
+}
+
+// ImportCampaigns writes the campaigns one at a time, each along with its rules. The targeting index is refreshed
+// once for the whole batch, also for the campaigns written before a failing one.
+func (s *Store) ImportCampaigns(ctx context.Context, imports []models.CampaignImport) error {
+	var written []string
+	var err error
+	rules := false
+	for _, imported := range imports {
+		if err = s.Repository.ImportCampaign(ctx, imported.Campaign, imported.Rule); err != nil {
+			break
+		}
+
+		rules = rules || imported.Rule != nil
+
+		if imported.Campaign != nil {
+			written = append(written, imported.Campaign.CampaignID)
+		} else {
+			written = append(written, imported.Rule.CampaignID)
+		}
+	}
+
+	if len(written) == 0 {
+		return err
+	}
+
+	if rules {
+		s.rulesChanged(ctx, written...)
+	} else {
+		s.campaignsChanged(ctx, written...)
+	}
+
+	return err
+}
@@ -0,0 +1,385 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// targetingIndex holds the active campaigns and their rules compiled into bitsets, so that a delivery request is
+// answered by intersecting sets instead of querying MongoDB. The snapshot is rebuilt as a whole and swapped, readers
+// never lock.
+type targetingIndex struct {
+	snapshot atomic.Pointer[indexSnapshot]
+	// refresh serializes the refreshes from load to swap, so that a snapshot loaded before a write is never swapped in
+	// after one loaded since.
+	refresh sync.Mutex
+	// pending holds the campaigns whose cache couldn't be invalidated, retried on every refresh until it is. Guarded
+	// by refresh.
+	pending map[string]bool
+	// rulesChanged is set by the writes of rules until the rules version is bumped, retried on every refresh too.
+	// Guarded by refresh.
+	rulesChanged bool
+}
+
+type indexSnapshot struct {
+	campaigns []models.Response
+	// schedules holds the start and end of the scheduled campaigns by position, they are checked at match time.
+	schedules map[int][2]*time.Time
+	// evaluated holds the daypart and version rules by position, they are evaluated at match time too.
+	evaluated map[int][]models.Rule
+	// expressions holds the parsed expressions by position.
+	expressions map[int]helpers.Expression
+	// patterns holds the compiled patterns of the evaluated rules and of the expressions.
+	patterns   patternSet
+	all        bitset
+	dimensions map[string]*dimensionIndex
+	// stored holds the IDs of every stored campaign, delivered or not.
+	stored map[string]bool
+	// versions fingerprints every stored campaign along with its rules, indexed or not, to tell which campaigns
+	// changed between two snapshots.
+	versions map[string]string
+}
+
+// dimensionIndex follows the semantics of createDimensionRule: a campaign matches a value when it has no rule with
+// values on the dimension, when the value is in its include list or when it has an exclude list without the value.
+// An unspecified value only matches the unrestricted campaigns.
+type dimensionIndex struct {
+	unrestricted bitset
+	excluding    bitset
+	include      map[string]bitset
+	exclude      map[string]bitset
+	// The patterns are compiled when the index is built and tried one by one against the value.
+	includePatterns []indexedPattern
+	excludePatterns []indexedPattern
+}
+
+type indexedPattern struct {
+	pattern  *regexp.Regexp
+	position int
+}
+
+func (i *targetingIndex) load() *indexSnapshot {
+	return i.snapshot.Load()
+}
+
+func (i *targetingIndex) swap(snapshot *indexSnapshot) *indexSnapshot {
+	return i.snapshot.Swap(snapshot)
+}
+
+// buildIndex indexes the active campaigns having targeting rules, campaigns without rules are never delivered.
+func buildIndex(campaigns []models.Campaign, rules []models.TargetingRule) *indexSnapshot {
+	rulesByCampaign := make(map[string]models.TargetingRule, len(rules))
+	for _, rule := range rules {
+		rulesByCampaign[rule.CampaignID] = rule
+	}
+
+	snapshot := &indexSnapshot{schedules: make(map[int][2]*time.Time), evaluated: make(map[int][]models.Rule),
+		expressions: make(map[int]helpers.Expression), patterns: make(patternSet),
+		versions:   fingerprint(campaigns, rules),
+		stored:     make(map[string]bool, len(campaigns)),
+		dimensions: make(map[string]*dimensionIndex, len(constants.Dimensions))}
+
+	// The campaigns are indexed in the order they are delivered, the matches come out ranked.
+	campaigns = slices.Clone(campaigns)
+	slices.SortFunc(campaigns, func(a, b models.Campaign) int { return compareRank(response(a), response(b)) })
+
+	var indexed [][]models.Rule
+	for _, campaign := range campaigns {
+		snapshot.stored[campaign.CampaignID] = true
+
+		targetingRule, ok := rulesByCampaign[campaign.CampaignID]
+		if !ok || campaign.Status != constants.StatusActive {
+			continue
+		}
+
+		// An invalid expression, which the validation doesn't let in, never holds.
+		if targetingRule.Expression != "" {
+			expression, err := helpers.ParseExpression(targetingRule.Expression)
+			if err != nil {
+				continue
+			}
+
+			snapshot.expressions[len(snapshot.campaigns)] = expression
+			for _, condition := range expression.Conditions() {
+				snapshot.patterns.add(condition.Dimension, condition.Values)
+			}
+		}
+
+		campaignRules := targetingRule.Rules
+
+		if campaign.StartAt != nil || campaign.EndAt != nil {
+			snapshot.schedules[len(snapshot.campaigns)] = [2]*time.Time{campaign.StartAt, campaign.EndAt}
+		}
+
+		if evaluated := evaluatedRules(campaignRules); len(evaluated) > 0 {
+			snapshot.evaluated[len(snapshot.campaigns)] = evaluated
+			for _, rule := range evaluated {
+				snapshot.patterns.add(rule.Dimension, rule.Include)
+				snapshot.patterns.add(rule.Dimension, rule.Exclude)
+			}
+		}
+
+		snapshot.campaigns = append(snapshot.campaigns, response(campaign))
+		indexed = append(indexed, campaignRules)
+	}
+
+	size := len(snapshot.campaigns)
+	snapshot.all = newBitset(size)
+
+	for _, dimension := range queriedDimensions() {
+		snapshot.dimensions[dimension] = &dimensionIndex{unrestricted: newBitset(size), excluding: newBitset(size),
+			include: make(map[string]bitset), exclude: make(map[string]bitset)}
+	}
+
+	for position, campaignRules := range indexed {
+		snapshot.all.set(position)
+
+		restricted := make(map[string]bool)
+		for _, rule := range campaignRules {
+			index, ok := snapshot.dimensions[rule.Dimension]
+			if !ok || (len(rule.Include) == 0 && len(rule.Exclude) == 0) {
+				continue
+			}
+
+			// Rules saved before countries were canonical may have their names.
+			include, exclude := rule.Include, rule.Exclude
+			if rule.Dimension == constants.Country {
+				include, exclude = helpers.CanonicalCountries(include), helpers.CanonicalCountries(exclude)
+			}
+
+			restricted[rule.Dimension] = true
+			index.includePatterns = addValues(index.include, index.includePatterns, include, position, size)
+			index.excludePatterns = addValues(index.exclude, index.excludePatterns, exclude, position, size)
+
+			if len(rule.Exclude) > 0 {
+				index.excluding.set(position)
+			}
+		}
+
+		for dimension, index := range snapshot.dimensions {
+			if !restricted[dimension] {
+				index.unrestricted.set(position)
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// addValues adds the position to the set of each value, and returns the patterns along with the ones among the values.
+// An invalid pattern, which the validation doesn't let in, never matches.
+func addValues(sets map[string]bitset, patterns []indexedPattern, values []string, position, size int) []indexedPattern {
+	for _, value := range values {
+		if helpers.IsPattern(value) {
+			if pattern, err := helpers.CompilePattern(value); err == nil {
+				patterns = append(patterns, indexedPattern{pattern: pattern, position: position})
+			}
+
+			continue
+		}
+
+		set, ok := sets[value]
+		if !ok {
+			set = newBitset(size)
+			sets[value] = set
+		}
+
+		set.set(position)
+	}
+
+	return patterns
+}
+
+func fingerprint(campaigns []models.Campaign, rules []models.TargetingRule) map[string]string {
+	versions := make(map[string]string, len(campaigns))
+
+	for _, campaign := range campaigns {
+		version, _ := json.Marshal(campaign)
+		versions[campaign.CampaignID] = string(version)
+	}
+
+	for _, rule := range rules {
+		version, _ := json.Marshal(rule.Rules)
+		versions[rule.CampaignID] += string(version) + rule.Expression
+	}
+
+	return versions
+}
+
+// changedCampaigns lists the campaigns added, updated or removed between the snapshots.
+func changedCampaigns(previous, current *indexSnapshot) []string {
+	var changed []string
+
+	for campaignID, version := range current.versions {
+		if previous.versions[campaignID] != version {
+			changed = append(changed, campaignID)
+		}
+	}
+
+	for campaignID := range previous.versions {
+		if _, ok := current.versions[campaignID]; !ok {
+			changed = append(changed, campaignID)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+// match returns the campaigns matching every dimension, scheduled at now and with a daypart including the local time
+// of the request, ranked like they were indexed. The indexed dimensions are matched first, then the evaluated rules
+// and the expressions of the remaining campaigns.
+func (s *indexSnapshot) match(dimensions *models.Dimension, now time.Time) []models.Response {
+	result := s.all.clone()
+
+	for dimension, index := range s.dimensions {
+		value := dimensionValue(dimensions, dimension)
+
+		matched := index.unrestricted.clone()
+		if value != "" {
+			matched.or(index.include[value])
+
+			notExcluded := index.excluding.clone()
+			notExcluded.andNot(index.exclude[value])
+
+			for _, pattern := range index.includePatterns {
+				if pattern.pattern.MatchString(value) {
+					matched.set(pattern.position)
+				}
+			}
+
+			for _, pattern := range index.excludePatterns {
+				if pattern.pattern.MatchString(value) {
+					notExcluded.clear(pattern.position)
+				}
+			}
+
+			matched.or(notExcluded)
+		}
+
+		result.and(matched)
+		if result.isEmpty() {
+			return nil
+		}
+	}
+
+	local := localTime(dimensions, now)
+
+	var campaigns []models.Response
+	result.each(func(i int) {
+		if schedule, ok := s.schedules[i]; ok && !inSchedule(schedule[0], schedule[1], now) {
+			return
+		}
+
+		if rules, ok := s.evaluated[i]; ok && !matchEvaluated(rules, dimensions, local, s.patterns) {
+			return
+		}
+
+		if expression, ok := s.expressions[i]; ok && !expression.Evaluate(conditionHolds(dimensions, s.patterns)) {
+			return
+		}
+
+		campaigns = append(campaigns, s.campaigns[i])
+	})
+
+	return campaigns
+}
+
+// RefreshIndex rebuilds the targeting index from the campaigns and rules of the repository and invalidates the cached
+// delivery responses of the campaigns that changed since the previous build. Until it succeeds once, delivery is
+// served from the repository and Redis. Concurrent refreshes wait for each other.
+func (s *Store) RefreshIndex(ctx context.Context) ([]string, error) {
+	s.index.refresh.Lock()
+	defer s.index.refresh.Unlock()
+
+	campaigns, err := s.ListCampaigns(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := buildIndex(campaigns, rules)
+	previous := s.index.swap(snapshot)
+
+	if previous == nil {
+		s.logger.Info("Targeting index loaded", "campaigns", len(snapshot.campaigns))
+		s.invalidatePending(ctx, nil)
+		return nil, nil
+	}
+
+	// The index is already serving the change, a cache that can't be invalidated only matters to the fallback and is
+	// retried on the next refresh.
+	changed := changedCampaigns(previous, snapshot)
+	s.invalidatePending(ctx, changed)
+
+	s.logger.Debug("Targeting index refreshed", "campaigns", len(snapshot.campaigns), "changed", changed)
+
+	return changed, nil
+}
+
+// invalidatePending invalidates the cache of the changed campaigns and of the ones that failed before, and the cache
+// of every response after a write of rules, keeping those failing again for the next refresh. It is called with the refresh lock held.
+func (s *Store) invalidatePending(ctx context.Context, changed []string) {
+	if s.index.pending == nil {
+		s.index.pending = make(map[string]bool)
+	}
+
+	for _, campaignID := range changed {
+		s.index.pending[campaignID] = true
+	}
+
+	if s.index.rulesChanged {
+		if err := s.bumpRulesVersion(ctx); err != nil {
+			s.logger.Warn("Error while Bumping rules version", "Error", err.Error())
+		} else {
+			s.index.rulesChanged = false
+		}
+	}
+
+	for campaignID := range s.index.pending {
+		if err := s.InvalidateCampaignCache(ctx, campaignID); err != nil {
+			s.logger.Warn("Error while Invalidating cache of changed campaign", "campaignID", campaignID, "Error", err.Error())
+			continue
+		}
+
+		delete(s.index.pending, campaignID)
+	}
+}
+
+// campaignsChanged is called after every write of campaigns or their rules, so that delivery never serves stale
+// data. The write is already done, so neither an unavailable cache nor a failed refresh fails it: the cache is left to
+// the refreshes to retry, and the index to the watcher to rebuild.
+func (s *Store) campaignsChanged(ctx context.Context, campaignIDs ...string) {
+	s.index.refresh.Lock()
+	s.invalidatePending(ctx, campaignIDs)
+	s.index.refresh.Unlock()
+
+	if _, err := s.RefreshIndex(ctx); err != nil {
+		s.logger.Error("Error while Refreshing targeting index", "campaignIDs", campaignIDs, "Error", err.Error())
+	}
+}
+
+// rulesChanged is called after every write of rules that can make the campaigns match more dimensions, whose cached
+// responses aren't keyed under the campaigns yet, so the cache moves to a new version of the rules.
+func (s *Store) rulesChanged(ctx context.Context, campaignIDs ...string) {
+	s.index.refresh.Lock()
+	s.index.rulesChanged = true
+	s.index.refresh.Unlock()
+
+	s.campaignsChanged(ctx, campaignIDs...)
+}
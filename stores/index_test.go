@@ -0,0 +1,317 @@
+package stores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+func readTestdata(t *testing.T) ([]models.Campaign, []models.TargetingRule) {
+	t.Helper()
+
+	campaigns, err := loadCampaigns("./testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := loadRules("./testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return campaigns, rules
+}
+
+func TestIndex_Ranking(t *testing.T) {
+	campaigns := []models.Campaign{
+		{CampaignID: "zepto", Status: "ACTIVE"},
+		{CampaignID: "spotify", Status: "ACTIVE", Priority: 1},
+		{CampaignID: "netflix", Status: "ACTIVE", Priority: 5},
+		{CampaignID: "duolingo", Status: "ACTIVE", Priority: 1, Weight: 3},
+		{CampaignID: "amazonprime", Status: "ACTIVE"},
+	}
+
+	var rules []models.TargetingRule
+	for _, campaign := range campaigns {
+		rules = append(rules, models.TargetingRule{CampaignID: campaign.CampaignID, Rules: []models.Rule{}})
+	}
+
+	var campaignIDs []string
+	for _, campaign := range buildIndex(campaigns, rules).match(&models.Dimension{APPID: "app", OS: "ios"}, time.Now()) {
+		campaignIDs = append(campaignIDs, campaign.CampaignID)
+	}
+
+	assert.Equal(t, []string{"netflix", "duolingo", "spotify", "amazonprime", "zepto"}, campaignIDs)
+	assert.Equal(t, "zepto", campaigns[0].CampaignID, "the campaigns are ranked in a copy")
+}
+
+func TestIndex_Match(t *testing.T) {
+	campaigns, rules := readTestdata(t)
+	snapshot := buildIndex(campaigns, rules)
+
+	tests := []struct {
+		name       string
+		dimensions *models.Dimension
+		expected   []string
+	}{
+		{
+			name:       "only unrestricted and not excluded campaigns",
+			dimensions: &models.Dimension{APPID: "exampleapp", OS: "android", Country: "us"},
+			expected:   []string{"spotify"},
+		},
+		{
+			name:       "included app",
+			dimensions: &models.Dimension{APPID: "com.whatsapp", OS: "ios", Country: "in"},
+			expected:   []string{"duolingo", "whatsapp"},
+		},
+		{
+			name:       "inactive campaign is not delivered",
+			dimensions: &models.Dimension{APPID: "com.zhiliaoapp.musically", OS: "ios", Country: "us"},
+			expected:   []string{"netflix", "spotify"},
+		},
+		{
+			name:       "nothing matches",
+			dimensions: &models.Dimension{APPID: "nonexistentapp", OS: "windows", Country: "kp"},
+			expected:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var campaignIDs []string
+			for _, campaign := range snapshot.match(tt.dimensions, time.Now()) {
+				campaignIDs = append(campaignIDs, campaign.CampaignID)
+			}
+
+			assert.Equal(t, tt.expected, campaignIDs)
+		})
+	}
+}
+
+// TestIndex_MatchesExplain checks that the index agrees with the rule evaluation for every combination of values.
+func TestIndex_MatchesExplain(t *testing.T) {
+	campaigns, rules := readTestdata(t)
+	snapshot := buildIndex(campaigns, rules)
+
+	rulesByCampaign := make(map[string]*models.TargetingRule)
+	values := map[string][]string{"app": {"unknown"}, "os": {"unknown"}, "country": {"unknown"}}
+	for i, rule := range rules {
+		rulesByCampaign[rule.CampaignID] = &rules[i]
+
+		for _, r := range rule.Rules {
+			values[r.Dimension] = append(values[r.Dimension], r.Include...)
+			values[r.Dimension] = append(values[r.Dimension], r.Exclude...)
+		}
+	}
+
+	for _, app := range values["app"] {
+		for _, os := range values["os"] {
+			for _, country := range values["country"] {
+				dimensions := &models.Dimension{APPID: app, OS: os, Country: country}
+
+				var expected []models.Response
+				for i := range campaigns {
+					if explain(&campaigns[i], rulesByCampaign[campaigns[i].CampaignID], dimensions, time.Now()).Delivered {
+						expected = append(expected, models.Response{CampaignID: campaigns[i].CampaignID,
+							Image: campaigns[i].Image, CTA: campaigns[i].CTA})
+					}
+				}
+
+				assert.ElementsMatch(t, expected, snapshot.match(dimensions, time.Now()), "dimensions %+v", *dimensions)
+			}
+		}
+	}
+}
+
+func TestIndex_MatchOptionalDimensions(t *testing.T) {
+	campaigns, rules := readTestdata(t)
+	for i := range rules {
+		switch rules[i].CampaignID {
+		case "spotify":
+			rules[i].Rules = append(rules[i].Rules, models.Rule{Dimension: "lang", Exclude: []string{"fr"}})
+		case "netflix":
+			rules[i].Rules = append(rules[i].Rules, models.Rule{Dimension: "device", Include: []string{"tv"}},
+				models.Rule{Dimension: "os_version", Include: []string{">=12"}})
+		}
+	}
+
+	snapshot := buildIndex(campaigns, rules)
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		dimensions *models.Dimension
+		expected   []string
+	}{
+		{
+			name:       "unspecified values only match campaigns without a rule",
+			dimensions: &models.Dimension{APPID: "com.zhiliaoapp.musically", OS: "ios", Country: "gb"},
+			expected:   []string{"duolingo"},
+		},
+		{
+			name: "specified values",
+			dimensions: &models.Dimension{APPID: "com.zhiliaoapp.musically", OS: "ios", Country: "gb", OSVersion: "13.1",
+				Device: "tv", Lang: "en"},
+			expected: []string{"duolingo", "netflix"},
+		},
+		{
+			name: "version out of range",
+			dimensions: &models.Dimension{APPID: "com.zhiliaoapp.musically", OS: "ios", Country: "gb", OSVersion: "11",
+				Device: "tv", Lang: "en"},
+			expected: []string{"duolingo"},
+		},
+		{
+			name:       "excluded language",
+			dimensions: &models.Dimension{APPID: "exampleapp", OS: "android", Country: "us", Lang: "fr"},
+			expected:   nil,
+		},
+		{
+			name:       "not excluded language",
+			dimensions: &models.Dimension{APPID: "exampleapp", OS: "android", Country: "us", Lang: "en"},
+			expected:   []string{"spotify"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, responseIDs(snapshot.match(tt.dimensions, now)))
+		})
+	}
+}
+
+func TestIndex_MatchPatterns(t *testing.T) {
+	campaigns := []models.Campaign{
+		{CampaignID: "google", Status: "ACTIVE"},
+		{CampaignID: "nogoogle", Status: "ACTIVE"},
+		{CampaignID: "regex", Status: "ACTIVE"},
+	}
+	rules := []models.TargetingRule{
+		{CampaignID: "google", Rules: []models.Rule{{Dimension: "app", Include: []string{"com.google.*", "com.whatsapp"}}}},
+		{CampaignID: "nogoogle", Rules: []models.Rule{{Dimension: "app", Exclude: []string{"com.google.*"}}}},
+		{CampaignID: "regex", Rules: []models.Rule{{Dimension: "app", Include: []string{`/com\.(amazon|ubercab)(\..+)?/`}},
+			{Dimension: "lang", Include: []string{"en-??"}}}},
+	}
+
+	snapshot := buildIndex(campaigns, rules)
+	now := time.Now()
+
+	tests := []struct {
+		app      string
+		lang     string
+		expected []string
+	}{
+		{app: "com.google.android.apps.maps", expected: []string{"google"}},
+		{app: "com.whatsapp", expected: []string{"google", "nogoogle"}},
+		{app: "com.googlex", expected: []string{"nogoogle"}},
+		{app: "com.amazon.avod", lang: "en-us", expected: []string{"nogoogle", "regex"}},
+		{app: "com.ubercab", lang: "en", expected: []string{"nogoogle"}},
+		{app: "xcom.amazon", lang: "en-gb", expected: []string{"nogoogle"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.app, func(t *testing.T) {
+			dimensions := &models.Dimension{APPID: tt.app, OS: "ios", Country: "us", Lang: tt.lang}
+
+			assert.Equal(t, tt.expected, responseIDs(snapshot.match(dimensions, now)))
+
+			var explained []string
+			for i := range campaigns {
+				if explain(&campaigns[i], &rules[i], dimensions, now).Delivered {
+					explained = append(explained, campaigns[i].CampaignID)
+				}
+			}
+
+			assert.Equal(t, tt.expected, explained)
+		})
+	}
+}
+
+func TestIndex_CompiledPatterns(t *testing.T) {
+	campaigns := []models.Campaign{{CampaignID: "french", Status: "ACTIVE"}}
+	rules := []models.TargetingRule{{CampaignID: "french", Rules: []models.Rule{
+		{Dimension: "app", Include: []string{"com.google.*"}},
+		{Dimension: "app_version", Include: []string{"2.*"}},
+	}, Expression: "lang = 'fr-*' or country = ca"}}
+
+	snapshot := buildIndex(campaigns, rules)
+
+	// The indexed dimensions keep their patterns in the index, the evaluated rules and the expressions in the set.
+	var compiled []string
+	for pattern := range snapshot.patterns {
+		compiled = append(compiled, pattern)
+	}
+
+	assert.ElementsMatch(t, []string{"2.*", "fr-*"}, compiled)
+
+	dimensions := &models.Dimension{APPID: "com.google.maps", AppVersion: "2.4", Lang: "fr-ca", Country: "fr"}
+	assert.Equal(t, []string{"french"}, responseIDs(snapshot.match(dimensions, time.Now())))
+
+	dimensions.Lang = "en-ca"
+	assert.Empty(t, snapshot.match(dimensions, time.Now()))
+}
+
+func TestIndex_MatchExpression(t *testing.T) {
+	campaigns := []models.Campaign{
+		{CampaignID: "northamerica", Status: "ACTIVE"},
+		{CampaignID: "notablets", Status: "ACTIVE"},
+		{CampaignID: "invalid", Status: "ACTIVE"},
+	}
+	rules := []models.TargetingRule{
+		{CampaignID: "northamerica", Rules: []models.Rule{{Dimension: "app", Include: []string{"com.spotify"}}},
+			Expression: "(country = us and os = ios) or country = ca"},
+		{CampaignID: "notablets", Rules: []models.Rule{},
+			Expression: "device != tablet and not (os = android and os_version < 10)"},
+		{CampaignID: "invalid", Rules: []models.Rule{}, Expression: "country ="},
+	}
+
+	snapshot := buildIndex(campaigns, rules)
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		dimensions *models.Dimension
+		expected   []string
+	}{
+		{name: "first branch", dimensions: &models.Dimension{APPID: "com.spotify", Country: "us", OS: "ios"},
+			expected: []string{"northamerica", "notablets"}},
+		{name: "second branch", dimensions: &models.Dimension{APPID: "com.spotify", Country: "ca", OS: "android",
+			OSVersion: "9"}, expected: []string{"northamerica"}},
+		{name: "neither branch", dimensions: &models.Dimension{APPID: "com.spotify", Country: "us", OS: "android",
+			Device: "tablet"}, expected: nil},
+		{name: "unspecified version", dimensions: &models.Dimension{APPID: "com.netflix", Country: "us", OS: "android"},
+			expected: []string{"notablets"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, responseIDs(snapshot.match(tt.dimensions, now)))
+
+			var explained []string
+			for i := range campaigns {
+				if explain(&campaigns[i], &rules[i], tt.dimensions, now).Delivered {
+					explained = append(explained, campaigns[i].CampaignID)
+				}
+			}
+
+			assert.Equal(t, tt.expected, explained)
+		})
+	}
+}
+
+func TestChangedCampaigns(t *testing.T) {
+	campaigns, rules := readTestdata(t)
+	previous := buildIndex(campaigns, rules)
+
+	campaigns[0].CTA = "Listen Later"
+	rules[1].Rules[0].Include = []string{"android"}
+	campaigns = append(campaigns[:2], campaigns[3:]...)
+	campaigns = append(campaigns, models.Campaign{CampaignID: "youtube", Status: "ACTIVE"})
+
+	current := buildIndex(campaigns, rules)
+
+	assert.Equal(t, []string{"duolingo", "spotify", "subwaysurfer", "youtube"}, changedCampaigns(previous, current))
+	assert.Empty(t, changedCampaigns(current, current))
+}
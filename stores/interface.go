@@ -1,10 +1,88 @@
 package stores
 
 import (
+	"context"
+	"time"
+
 	"github.com/Durga-Chikkala/delivery-service/models"
 	"github.com/gin-gonic/gin"
 )
 
 type Delivery interface {
 	Get(ctx *gin.Context, dimensions *models.Dimension) (*[]models.Response, error)
+	Explain(ctx context.Context, dimensions *models.Dimension, campaignID string) ([]models.Explanation, error)
+	ReserveDeliveries(ctx context.Context, userID string, campaigns []models.Response, limit int) []models.Response
+}
+
+// Pacing tells how far the campaigns with an impression goal are delivered.
+type Pacing interface {
+	Pacing(ctx context.Context, campaigns []models.Campaign, now time.Time) ([]models.Pacing, error)
+}
+
+type Campaign interface {
+	CreateCampaign(ctx context.Context, campaign *models.Campaign) error
+	GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error)
+	ListCampaigns(ctx context.Context, status string) ([]models.Campaign, error)
+	UpdateCampaign(ctx context.Context, campaign *models.Campaign) error
+	DeleteCampaign(ctx context.Context, campaignID string) error
+}
+
+type Rule interface {
+	GetRules(ctx context.Context, campaignID string) (*models.TargetingRule, error)
+	SaveRules(ctx context.Context, rule *models.TargetingRule) error
+	DeleteRules(ctx context.Context, campaignID string) error
+	ListRules(ctx context.Context) ([]models.TargetingRule, error)
+}
+
+// CampaignLookup tells whether a campaign exists, whatever its status.
+type CampaignLookup interface {
+	CampaignExists(ctx context.Context, campaignID string) (bool, error)
+}
+
+// Events buffers the events of the delivered campaigns until they are written to the EventSink.
+type Events interface {
+	AddEvents(events []models.Event) error
+}
+
+// Nonces tells whether a beacon token is used for the first time, the replays being rejected. ReleaseNonce gives a
+// claimed nonce back when its event couldn't be recorded, so that the token can be retried.
+type Nonces interface {
+	ClaimNonce(ctx context.Context, nonce string, until time.Time) (bool, error)
+	ReleaseNonce(ctx context.Context, nonce string) error
+}
+
+type EventSink interface {
+	WriteEvents(ctx context.Context, events []models.Event) error
+}
+
+type Import interface {
+	ImportCampaign(ctx context.Context, campaign *models.Campaign, rule *models.TargetingRule) error
+}
+
+// Importer writes the campaigns of an import as a batch, delivery being refreshed once for all of them.
+type Importer interface {
+	ImportCampaigns(ctx context.Context, imports []models.CampaignImport) error
+}
+
+// Repository holds the campaigns and their targeting rules. MatchRules returns the IDs of the campaigns whose rules
+// match the dimensions, whatever their status.
+type Repository interface {
+	Campaign
+	Rule
+	Import
+	Backend() string
+	Ping(ctx context.Context) error
+	MatchRules(ctx context.Context, dimensions *models.Dimension) ([]string, error)
+	FindActiveCampaignsByIDs(ctx context.Context, campaignIDs []string) (*[]models.Response, error)
+	NextScheduleBoundary(ctx context.Context, campaignIDs []string, now time.Time) (*time.Time, error)
+}
+
+// Geo resolves the country of a request without the country param.
+type Geo interface {
+	ResolveCountry(country, clientIP string) string
+}
+
+type Health interface {
+	CheckDependencies(ctx context.Context) []models.DependencyStatus
+	IndexLoaded() bool
 }
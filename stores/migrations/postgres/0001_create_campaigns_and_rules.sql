@@ -0,0 +1,25 @@
+CREATE TABLE campaigns (
+    campaign_id TEXT PRIMARY KEY,
+    name        TEXT NOT NULL DEFAULT '',
+    image       TEXT NOT NULL DEFAULT '',
+    cta         TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL
+);
+
+CREATE INDEX campaigns_status_idx ON campaigns (status);
+
+-- A row in targeting_rules tells the campaign has a rules document, even one without any rule, like in MongoDB.
+CREATE TABLE targeting_rules (
+    campaign_id TEXT PRIMARY KEY
+);
+
+CREATE TABLE rules (
+    campaign_id TEXT    NOT NULL REFERENCES targeting_rules (campaign_id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL,
+    dimension   TEXT    NOT NULL,
+    include     TEXT[]  NOT NULL DEFAULT '{}',
+    exclude     TEXT[]  NOT NULL DEFAULT '{}',
+    PRIMARY KEY (campaign_id, position)
+);
+
+CREATE INDEX rules_dimension_idx ON rules (campaign_id, dimension);
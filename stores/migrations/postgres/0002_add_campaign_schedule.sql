@@ -0,0 +1,4 @@
+ALTER TABLE campaigns
+    ADD COLUMN start_at TIMESTAMPTZ,
+    ADD COLUMN end_at   TIMESTAMPTZ,
+    ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- The time windows of daypart rules, as a JSON array of {"days", "start", "end"}.
+ALTER TABLE rules ADD COLUMN windows JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+-- The boolean expression a campaign must satisfy on top of its rules, empty when it has none.
+ALTER TABLE targeting_rules ADD COLUMN expression TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE campaigns
+    ADD COLUMN priority INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN weight   INTEGER NOT NULL DEFAULT 0;
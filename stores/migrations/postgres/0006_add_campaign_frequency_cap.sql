@@ -0,0 +1,2 @@
+ALTER TABLE campaigns
+    ADD COLUMN frequency_cap JSONB;
@@ -0,0 +1,2 @@
+ALTER TABLE campaigns
+    ADD COLUMN impression_goal JSONB;
@@ -5,7 +5,9 @@
 package stores
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Durga-Chikkala/delivery-service/models"
 	gin "github.com/gin-gonic/gin"
@@ -35,6 +37,21 @@ func (m *MockDelivery) EXPECT() *MockDeliveryMockRecorder {
 	return m.recorder
 }
 
+// Explain mocks base method.
+func (m *MockDelivery) Explain(ctx context.Context, dimensions *models.Dimension, campaignID string) ([]models.Explanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Explain", ctx, dimensions, campaignID)
+	ret0, _ := ret[0].([]models.Explanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Explain indicates an expected call of Explain.
+func (mr *MockDeliveryMockRecorder) Explain(ctx, dimensions, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Explain", reflect.TypeOf((*MockDelivery)(nil).Explain), ctx, dimensions, campaignID)
+}
+
 // Get mocks base method.
 func (m *MockDelivery) Get(ctx *gin.Context, dimensions *models.Dimension) (*[]models.Response, error) {
 	m.ctrl.T.Helper()
@@ -49,3 +66,797 @@ func (mr *MockDeliveryMockRecorder) Get(ctx, dimensions interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDelivery)(nil).Get), ctx, dimensions)
 }
+
+// ReserveDeliveries mocks base method.
+func (m *MockDelivery) ReserveDeliveries(ctx context.Context, userID string, campaigns []models.Response, limit int) []models.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveDeliveries", ctx, userID, campaigns, limit)
+	ret0, _ := ret[0].([]models.Response)
+	return ret0
+}
+
+// ReserveDeliveries indicates an expected call of ReserveDeliveries.
+func (mr *MockDeliveryMockRecorder) ReserveDeliveries(ctx, userID, campaigns, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveDeliveries", reflect.TypeOf((*MockDelivery)(nil).ReserveDeliveries), ctx, userID, campaigns, limit)
+}
+
+// MockPacing is a mock of Pacing interface.
+type MockPacing struct {
+	ctrl     *gomock.Controller
+	recorder *MockPacingMockRecorder
+}
+
+// MockPacingMockRecorder is the mock recorder for MockPacing.
+type MockPacingMockRecorder struct {
+	mock *MockPacing
+}
+
+// NewMockPacing creates a new mock instance.
+func NewMockPacing(ctrl *gomock.Controller) *MockPacing {
+	mock := &MockPacing{ctrl: ctrl}
+	mock.recorder = &MockPacingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPacing) EXPECT() *MockPacingMockRecorder {
+	return m.recorder
+}
+
+// Pacing mocks base method.
+func (m *MockPacing) Pacing(ctx context.Context, campaigns []models.Campaign, now time.Time) ([]models.Pacing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pacing", ctx, campaigns, now)
+	ret0, _ := ret[0].([]models.Pacing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pacing indicates an expected call of Pacing.
+func (mr *MockPacingMockRecorder) Pacing(ctx, campaigns, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pacing", reflect.TypeOf((*MockPacing)(nil).Pacing), ctx, campaigns, now)
+}
+
+// MockCampaign is a mock of Campaign interface.
+type MockCampaign struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignMockRecorder
+}
+
+// MockCampaignMockRecorder is the mock recorder for MockCampaign.
+type MockCampaignMockRecorder struct {
+	mock *MockCampaign
+}
+
+// NewMockCampaign creates a new mock instance.
+func NewMockCampaign(ctrl *gomock.Controller) *MockCampaign {
+	mock := &MockCampaign{ctrl: ctrl}
+	mock.recorder = &MockCampaignMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaign) EXPECT() *MockCampaignMockRecorder {
+	return m.recorder
+}
+
+// CreateCampaign mocks base method.
+func (m *MockCampaign) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockCampaignMockRecorder) CreateCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockCampaign)(nil).CreateCampaign), ctx, campaign)
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockCampaign) DeleteCampaign(ctx context.Context, campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", ctx, campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockCampaignMockRecorder) DeleteCampaign(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockCampaign)(nil).DeleteCampaign), ctx, campaignID)
+}
+
+// GetCampaign mocks base method.
+func (m *MockCampaign) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, campaignID)
+	ret0, _ := ret[0].(*models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockCampaignMockRecorder) GetCampaign(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockCampaign)(nil).GetCampaign), ctx, campaignID)
+}
+
+// ListCampaigns mocks base method.
+func (m *MockCampaign) ListCampaigns(ctx context.Context, status string) ([]models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaigns", ctx, status)
+	ret0, _ := ret[0].([]models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaigns indicates an expected call of ListCampaigns.
+func (mr *MockCampaignMockRecorder) ListCampaigns(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaigns", reflect.TypeOf((*MockCampaign)(nil).ListCampaigns), ctx, status)
+}
+
+// UpdateCampaign mocks base method.
+func (m *MockCampaign) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign.
+func (mr *MockCampaignMockRecorder) UpdateCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockCampaign)(nil).UpdateCampaign), ctx, campaign)
+}
+
+// MockRule is a mock of Rule interface.
+type MockRule struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleMockRecorder
+}
+
+// MockRuleMockRecorder is the mock recorder for MockRule.
+type MockRuleMockRecorder struct {
+	mock *MockRule
+}
+
+// NewMockRule creates a new mock instance.
+func NewMockRule(ctrl *gomock.Controller) *MockRule {
+	mock := &MockRule{ctrl: ctrl}
+	mock.recorder = &MockRuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRule) EXPECT() *MockRuleMockRecorder {
+	return m.recorder
+}
+
+// DeleteRules mocks base method.
+func (m *MockRule) DeleteRules(ctx context.Context, campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRules", ctx, campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRules indicates an expected call of DeleteRules.
+func (mr *MockRuleMockRecorder) DeleteRules(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRules", reflect.TypeOf((*MockRule)(nil).DeleteRules), ctx, campaignID)
+}
+
+// GetRules mocks base method.
+func (m *MockRule) GetRules(ctx context.Context, campaignID string) (*models.TargetingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", ctx, campaignID)
+	ret0, _ := ret[0].(*models.TargetingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockRuleMockRecorder) GetRules(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockRule)(nil).GetRules), ctx, campaignID)
+}
+
+// ListRules mocks base method.
+func (m *MockRule) ListRules(ctx context.Context) ([]models.TargetingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx)
+	ret0, _ := ret[0].([]models.TargetingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockRuleMockRecorder) ListRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockRule)(nil).ListRules), ctx)
+}
+
+// SaveRules mocks base method.
+func (m *MockRule) SaveRules(ctx context.Context, rule *models.TargetingRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRules", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRules indicates an expected call of SaveRules.
+func (mr *MockRuleMockRecorder) SaveRules(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRules", reflect.TypeOf((*MockRule)(nil).SaveRules), ctx, rule)
+}
+
+// MockCampaignLookup is a mock of CampaignLookup interface.
+type MockCampaignLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignLookupMockRecorder
+}
+
+// MockCampaignLookupMockRecorder is the mock recorder for MockCampaignLookup.
+type MockCampaignLookupMockRecorder struct {
+	mock *MockCampaignLookup
+}
+
+// NewMockCampaignLookup creates a new mock instance.
+func NewMockCampaignLookup(ctrl *gomock.Controller) *MockCampaignLookup {
+	mock := &MockCampaignLookup{ctrl: ctrl}
+	mock.recorder = &MockCampaignLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaignLookup) EXPECT() *MockCampaignLookupMockRecorder {
+	return m.recorder
+}
+
+// CampaignExists mocks base method.
+func (m *MockCampaignLookup) CampaignExists(ctx context.Context, campaignID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CampaignExists", ctx, campaignID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CampaignExists indicates an expected call of CampaignExists.
+func (mr *MockCampaignLookupMockRecorder) CampaignExists(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CampaignExists", reflect.TypeOf((*MockCampaignLookup)(nil).CampaignExists), ctx, campaignID)
+}
+
+// MockEvents is a mock of Events interface.
+type MockEvents struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsMockRecorder
+}
+
+// MockEventsMockRecorder is the mock recorder for MockEvents.
+type MockEventsMockRecorder struct {
+	mock *MockEvents
+}
+
+// NewMockEvents creates a new mock instance.
+func NewMockEvents(ctrl *gomock.Controller) *MockEvents {
+	mock := &MockEvents{ctrl: ctrl}
+	mock.recorder = &MockEventsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvents) EXPECT() *MockEventsMockRecorder {
+	return m.recorder
+}
+
+// AddEvents mocks base method.
+func (m *MockEvents) AddEvents(events []models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvents", events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvents indicates an expected call of AddEvents.
+func (mr *MockEventsMockRecorder) AddEvents(events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvents", reflect.TypeOf((*MockEvents)(nil).AddEvents), events)
+}
+
+// MockNonces is a mock of Nonces interface.
+type MockNonces struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoncesMockRecorder
+}
+
+// MockNoncesMockRecorder is the mock recorder for MockNonces.
+type MockNoncesMockRecorder struct {
+	mock *MockNonces
+}
+
+// NewMockNonces creates a new mock instance.
+func NewMockNonces(ctrl *gomock.Controller) *MockNonces {
+	mock := &MockNonces{ctrl: ctrl}
+	mock.recorder = &MockNoncesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNonces) EXPECT() *MockNoncesMockRecorder {
+	return m.recorder
+}
+
+// ClaimNonce mocks base method.
+func (m *MockNonces) ClaimNonce(ctx context.Context, nonce string, until time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNonce", ctx, nonce, until)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNonce indicates an expected call of ClaimNonce.
+func (mr *MockNoncesMockRecorder) ClaimNonce(ctx, nonce, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNonce", reflect.TypeOf((*MockNonces)(nil).ClaimNonce), ctx, nonce, until)
+}
+
+// ReleaseNonce mocks base method.
+func (m *MockNonces) ReleaseNonce(ctx context.Context, nonce string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseNonce", ctx, nonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseNonce indicates an expected call of ReleaseNonce.
+func (mr *MockNoncesMockRecorder) ReleaseNonce(ctx, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseNonce", reflect.TypeOf((*MockNonces)(nil).ReleaseNonce), ctx, nonce)
+}
+
+// MockEventSink is a mock of EventSink interface.
+type MockEventSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventSinkMockRecorder
+}
+
+// MockEventSinkMockRecorder is the mock recorder for MockEventSink.
+type MockEventSinkMockRecorder struct {
+	mock *MockEventSink
+}
+
+// NewMockEventSink creates a new mock instance.
+func NewMockEventSink(ctrl *gomock.Controller) *MockEventSink {
+	mock := &MockEventSink{ctrl: ctrl}
+	mock.recorder = &MockEventSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventSink) EXPECT() *MockEventSinkMockRecorder {
+	return m.recorder
+}
+
+// WriteEvents mocks base method.
+func (m *MockEventSink) WriteEvents(ctx context.Context, events []models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteEvents indicates an expected call of WriteEvents.
+func (mr *MockEventSinkMockRecorder) WriteEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEvents", reflect.TypeOf((*MockEventSink)(nil).WriteEvents), ctx, events)
+}
+
+// MockImport is a mock of Import interface.
+type MockImport struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportMockRecorder
+}
+
+// MockImportMockRecorder is the mock recorder for MockImport.
+type MockImportMockRecorder struct {
+	mock *MockImport
+}
+
+// NewMockImport creates a new mock instance.
+func NewMockImport(ctrl *gomock.Controller) *MockImport {
+	mock := &MockImport{ctrl: ctrl}
+	mock.recorder = &MockImportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImport) EXPECT() *MockImportMockRecorder {
+	return m.recorder
+}
+
+// ImportCampaign mocks base method.
+func (m *MockImport) ImportCampaign(ctx context.Context, campaign *models.Campaign, rule *models.TargetingRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCampaign", ctx, campaign, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCampaign indicates an expected call of ImportCampaign.
+func (mr *MockImportMockRecorder) ImportCampaign(ctx, campaign, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCampaign", reflect.TypeOf((*MockImport)(nil).ImportCampaign), ctx, campaign, rule)
+}
+
+// MockImporter is a mock of Importer interface.
+type MockImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockImporterMockRecorder
+}
+
+// MockImporterMockRecorder is the mock recorder for MockImporter.
+type MockImporterMockRecorder struct {
+	mock *MockImporter
+}
+
+// NewMockImporter creates a new mock instance.
+func NewMockImporter(ctrl *gomock.Controller) *MockImporter {
+	mock := &MockImporter{ctrl: ctrl}
+	mock.recorder = &MockImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImporter) EXPECT() *MockImporterMockRecorder {
+	return m.recorder
+}
+
+// ImportCampaigns mocks base method.
+func (m *MockImporter) ImportCampaigns(ctx context.Context, imports []models.CampaignImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCampaigns", ctx, imports)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCampaigns indicates an expected call of ImportCampaigns.
+func (mr *MockImporterMockRecorder) ImportCampaigns(ctx, imports interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCampaigns", reflect.TypeOf((*MockImporter)(nil).ImportCampaigns), ctx, imports)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Backend mocks base method.
+func (m *MockRepository) Backend() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backend")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Backend indicates an expected call of Backend.
+func (mr *MockRepositoryMockRecorder) Backend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backend", reflect.TypeOf((*MockRepository)(nil).Backend))
+}
+
+// CreateCampaign mocks base method.
+func (m *MockRepository) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockRepositoryMockRecorder) CreateCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockRepository)(nil).CreateCampaign), ctx, campaign)
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockRepository) DeleteCampaign(ctx context.Context, campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", ctx, campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockRepositoryMockRecorder) DeleteCampaign(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockRepository)(nil).DeleteCampaign), ctx, campaignID)
+}
+
+// DeleteRules mocks base method.
+func (m *MockRepository) DeleteRules(ctx context.Context, campaignID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRules", ctx, campaignID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRules indicates an expected call of DeleteRules.
+func (mr *MockRepositoryMockRecorder) DeleteRules(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRules", reflect.TypeOf((*MockRepository)(nil).DeleteRules), ctx, campaignID)
+}
+
+// FindActiveCampaignsByIDs mocks base method.
+func (m *MockRepository) FindActiveCampaignsByIDs(ctx context.Context, campaignIDs []string) (*[]models.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveCampaignsByIDs", ctx, campaignIDs)
+	ret0, _ := ret[0].(*[]models.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveCampaignsByIDs indicates an expected call of FindActiveCampaignsByIDs.
+func (mr *MockRepositoryMockRecorder) FindActiveCampaignsByIDs(ctx, campaignIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveCampaignsByIDs", reflect.TypeOf((*MockRepository)(nil).FindActiveCampaignsByIDs), ctx, campaignIDs)
+}
+
+// GetCampaign mocks base method.
+func (m *MockRepository) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, campaignID)
+	ret0, _ := ret[0].(*models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockRepositoryMockRecorder) GetCampaign(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockRepository)(nil).GetCampaign), ctx, campaignID)
+}
+
+// GetRules mocks base method.
+func (m *MockRepository) GetRules(ctx context.Context, campaignID string) (*models.TargetingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", ctx, campaignID)
+	ret0, _ := ret[0].(*models.TargetingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockRepositoryMockRecorder) GetRules(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockRepository)(nil).GetRules), ctx, campaignID)
+}
+
+// ImportCampaign mocks base method.
+func (m *MockRepository) ImportCampaign(ctx context.Context, campaign *models.Campaign, rule *models.TargetingRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCampaign", ctx, campaign, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCampaign indicates an expected call of ImportCampaign.
+func (mr *MockRepositoryMockRecorder) ImportCampaign(ctx, campaign, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCampaign", reflect.TypeOf((*MockRepository)(nil).ImportCampaign), ctx, campaign, rule)
+}
+
+// ListCampaigns mocks base method.
+func (m *MockRepository) ListCampaigns(ctx context.Context, status string) ([]models.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaigns", ctx, status)
+	ret0, _ := ret[0].([]models.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaigns indicates an expected call of ListCampaigns.
+func (mr *MockRepositoryMockRecorder) ListCampaigns(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaigns", reflect.TypeOf((*MockRepository)(nil).ListCampaigns), ctx, status)
+}
+
+// ListRules mocks base method.
+func (m *MockRepository) ListRules(ctx context.Context) ([]models.TargetingRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx)
+	ret0, _ := ret[0].([]models.TargetingRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockRepositoryMockRecorder) ListRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockRepository)(nil).ListRules), ctx)
+}
+
+// MatchRules mocks base method.
+func (m *MockRepository) MatchRules(ctx context.Context, dimensions *models.Dimension) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchRules", ctx, dimensions)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchRules indicates an expected call of MatchRules.
+func (mr *MockRepositoryMockRecorder) MatchRules(ctx, dimensions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchRules", reflect.TypeOf((*MockRepository)(nil).MatchRules), ctx, dimensions)
+}
+
+// NextScheduleBoundary mocks base method.
+func (m *MockRepository) NextScheduleBoundary(ctx context.Context, campaignIDs []string, now time.Time) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextScheduleBoundary", ctx, campaignIDs, now)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextScheduleBoundary indicates an expected call of NextScheduleBoundary.
+func (mr *MockRepositoryMockRecorder) NextScheduleBoundary(ctx, campaignIDs, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextScheduleBoundary", reflect.TypeOf((*MockRepository)(nil).NextScheduleBoundary), ctx, campaignIDs, now)
+}
+
+// Ping mocks base method.
+func (m *MockRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepository)(nil).Ping), ctx)
+}
+
+// SaveRules mocks base method.
+func (m *MockRepository) SaveRules(ctx context.Context, rule *models.TargetingRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRules", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRules indicates an expected call of SaveRules.
+func (mr *MockRepositoryMockRecorder) SaveRules(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRules", reflect.TypeOf((*MockRepository)(nil).SaveRules), ctx, rule)
+}
+
+// UpdateCampaign mocks base method.
+func (m *MockRepository) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign.
+func (mr *MockRepositoryMockRecorder) UpdateCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockRepository)(nil).UpdateCampaign), ctx, campaign)
+}
+
+// MockGeo is a mock of Geo interface.
+type MockGeo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoMockRecorder
+}
+
+// MockGeoMockRecorder is the mock recorder for MockGeo.
+type MockGeoMockRecorder struct {
+	mock *MockGeo
+}
+
+// NewMockGeo creates a new mock instance.
+func NewMockGeo(ctrl *gomock.Controller) *MockGeo {
+	mock := &MockGeo{ctrl: ctrl}
+	mock.recorder = &MockGeoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeo) EXPECT() *MockGeoMockRecorder {
+	return m.recorder
+}
+
+// ResolveCountry mocks base method.
+func (m *MockGeo) ResolveCountry(country, clientIP string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCountry", country, clientIP)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResolveCountry indicates an expected call of ResolveCountry.
+func (mr *MockGeoMockRecorder) ResolveCountry(country, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCountry", reflect.TypeOf((*MockGeo)(nil).ResolveCountry), country, clientIP)
+}
+
+// MockHealth is a mock of Health interface.
+type MockHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthMockRecorder
+}
+
+// MockHealthMockRecorder is the mock recorder for MockHealth.
+type MockHealthMockRecorder struct {
+	mock *MockHealth
+}
+
+// NewMockHealth creates a new mock instance.
+func NewMockHealth(ctrl *gomock.Controller) *MockHealth {
+	mock := &MockHealth{ctrl: ctrl}
+	mock.recorder = &MockHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealth) EXPECT() *MockHealthMockRecorder {
+	return m.recorder
+}
+
+// CheckDependencies mocks base method.
+func (m *MockHealth) CheckDependencies(ctx context.Context) []models.DependencyStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDependencies", ctx)
+	ret0, _ := ret[0].([]models.DependencyStatus)
+	return ret0
+}
+
+// CheckDependencies indicates an expected call of CheckDependencies.
+func (mr *MockHealthMockRecorder) CheckDependencies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDependencies", reflect.TypeOf((*MockHealth)(nil).CheckDependencies), ctx)
+}
+
+// IndexLoaded mocks base method.
+func (m *MockHealth) IndexLoaded() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexLoaded")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IndexLoaded indicates an expected call of IndexLoaded.
+func (mr *MockHealthMockRecorder) IndexLoaded() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexLoaded", reflect.TypeOf((*MockHealth)(nil).IndexLoaded))
+}
@@ -0,0 +1,515 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+const (
+	// illegalOperationCode is returned by a standalone MongoDB server, which doesn't support transactions.
+	illegalOperationCode = 20
+	// changeStreamNotSupportedCode is returned by a standalone MongoDB server, which has no change streams.
+	changeStreamNotSupportedCode = 40573
+)
+
+// MongoRepository stores campaigns and their targeting rules in the campaigns and rules collections.
+type MongoRepository struct {
+	logger             *slog.Logger
+	ruleCollection     *mongo.Collection
+	campaignCollection *mongo.Collection
+}
+
+func NewMongoRepository(db *mongo.Database, logger *slog.Logger) *MongoRepository {
+	ruleCollection := db.Collection("rules")
+	campaignCollection := db.Collection("campaigns")
+
+	return &MongoRepository{logger: logger, ruleCollection: ruleCollection, campaignCollection: campaignCollection}
+}
+
+func (r *MongoRepository) Backend() string {
+	return constants.BackendMongo
+}
+
+func (r *MongoRepository) Ping(ctx context.Context) error {
+	return r.campaignCollection.Database().Client().Ping(ctx, nil)
+}
+
+// MatchRules returns the campaigns whose targeting rules match every dimension, whatever their status.
+func (r *MongoRepository) MatchRules(ctx context.Context, dimensions *models.Dimension) ([]string, error) {
+	dimensionRules := make([]bson.M, 0, len(constants.Dimensions))
+	for _, dimension := range queriedDimensions() {
+		dimensionRules = append(dimensionRules, createDimensionRule(dimension,
+			queriedValues(dimension, dimensionValue(dimensions, dimension))))
+	}
+
+	ruleFilter := bson.M{"$and": dimensionRules}
+
+	cur, err := r.ruleCollection.Find(ctx, ruleFilter)
+	if err != nil {
+		r.logger.Error("Error while Fetching Rules", "Error", err.Error())
+		return nil, mongoError(err)
+	}
+	defer cur.Close(ctx)
+
+	local := localTime(dimensions, time.Now())
+
+	var campaignIDs []string
+	for cur.Next(ctx) {
+		var rule models.TargetingRule
+		if err := cur.Decode(&rule); err != nil {
+			r.logger.Error("Error decoding rule:", "Error", err.Error())
+			continue
+		}
+
+		// The filter can't match daypart windows, version ranges, patterns and expressions, it lets through the
+		// documents having them and the rules are evaluated on the matched documents.
+		if matchRules(rule.Rules, rule.Expression, dimensions, local) {
+			campaignIDs = append(campaignIDs, rule.CampaignID)
+		}
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return campaignIDs, nil
+}
+
+func (r *MongoRepository) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	filter := bson.M{"campaign_id": campaign.CampaignID}
+
+	res, err := r.campaignCollection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": campaign}, options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("Error while Creating campaign", "campaignID", campaign.CampaignID, "Error", err.Error())
+		return mongoError(err)
+	}
+
+	if res.UpsertedCount == 0 {
+		return &helpers.Error{Code: "Entity Already Exists", StatusCode: http.StatusConflict,
+			Reason: "Campaign " + campaign.CampaignID + " already exists"}
+	}
+
+	return nil
+}
+
+func (r *MongoRepository) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	var campaign models.Campaign
+
+	err := r.campaignCollection.FindOne(ctx, bson.M{"campaign_id": campaignID}).Decode(&campaign)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, campaignNotFound(campaignID)
+	}
+
+	if err != nil {
+		r.logger.Error("Error while Fetching campaign", "campaignID", campaignID, "Error", err.Error())
+		return nil, mongoError(err)
+	}
+
+	return &campaign, nil
+}
+
+func (r *MongoRepository) ListCampaigns(ctx context.Context, status string) ([]models.Campaign, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	cur, err := r.campaignCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"campaign_id": 1}))
+	if err != nil {
+		r.logger.Error("Error while Fetching campaigns", "Error", err.Error())
+		return nil, mongoError(err)
+	}
+	defer cur.Close(ctx)
+
+	campaigns := make([]models.Campaign, 0)
+	for cur.Next(ctx) {
+		var campaign models.Campaign
+		if err := cur.Decode(&campaign); err != nil {
+			r.logger.Error("Error decoding campaign", "Error", err.Error())
+			continue
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return campaigns, nil
+}
+
+func (r *MongoRepository) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	res, err := r.campaignCollection.ReplaceOne(ctx, bson.M{"campaign_id": campaign.CampaignID}, campaign)
+	if err != nil {
+		r.logger.Error("Error while Updating campaign", "campaignID", campaign.CampaignID, "Error", err.Error())
+		return mongoError(err)
+	}
+
+	if res.MatchedCount == 0 {
+		return campaignNotFound(campaign.CampaignID)
+	}
+
+	return nil
+}
+
+// DeleteCampaign removes the campaign along with its targeting rules in a single transaction, as rules of a deleted
+// campaign can never match.
+func (r *MongoRepository) DeleteCampaign(ctx context.Context, campaignID string) error {
+	err := r.inTransaction(ctx, campaignID, func(ctx context.Context) error {
+		res, err := r.campaignCollection.DeleteOne(ctx, bson.M{"campaign_id": campaignID})
+		if err != nil {
+			return err
+		}
+
+		if res.DeletedCount == 0 {
+			return campaignNotFound(campaignID)
+		}
+
+		_, err = r.ruleCollection.DeleteOne(ctx, bson.M{"campaign_id": campaignID})
+
+		return err
+	})
+
+	var notFound *helpers.Error
+	if errors.As(err, &notFound) {
+		return err
+	}
+
+	if err != nil {
+		r.logger.Error("Error while Deleting campaign", "campaignID", campaignID, "Error", err.Error())
+		return mongoError(err)
+	}
+
+	return nil
+}
+
+func (r *MongoRepository) GetRules(ctx context.Context, campaignID string) (*models.TargetingRule, error) {
+	var rule models.TargetingRule
+
+	err := r.ruleCollection.FindOne(ctx, bson.M{"campaign_id": campaignID}).Decode(&rule)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, rulesNotFound(campaignID)
+	}
+
+	if err != nil {
+		r.logger.Error("Error while Fetching Rules", "campaignID", campaignID, "Error", err.Error())
+		return nil, mongoError(err)
+	}
+
+	return &rule, nil
+}
+
+func (r *MongoRepository) SaveRules(ctx context.Context, rule *models.TargetingRule) error {
+	_, err := r.ruleCollection.ReplaceOne(ctx, bson.M{"campaign_id": rule.CampaignID}, rule, options.Replace().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("Error while Saving Rules", "campaignID", rule.CampaignID, "Error", err.Error())
+		return mongoError(err)
+	}
+
+	return nil
+}
+
+func (r *MongoRepository) DeleteRules(ctx context.Context, campaignID string) error {
+	res, err := r.ruleCollection.DeleteOne(ctx, bson.M{"campaign_id": campaignID})
+	if err != nil {
+		r.logger.Error("Error while Deleting Rules", "campaignID", campaignID, "Error", err.Error())
+		return mongoError(err)
+	}
+
+	if res.DeletedCount == 0 {
+		return rulesNotFound(campaignID)
+	}
+
+	return nil
+}
+
+func (r *MongoRepository) ListRules(ctx context.Context) ([]models.TargetingRule, error) {
+	cur, err := r.ruleCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"campaign_id": 1}))
+	if err != nil {
+		r.logger.Error("Error while Fetching Rules", "Error", err.Error())
+		return nil, mongoError(err)
+	}
+	defer cur.Close(ctx)
+
+	rules := make([]models.TargetingRule, 0)
+	for cur.Next(ctx) {
+		var rule models.TargetingRule
+		if err := cur.Decode(&rule); err != nil {
+			r.logger.Error("Error decoding rule:", "Error", err.Error())
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return rules, nil
+}
+
+// ImportCampaign upserts the campaign and replaces its targeting rules in a single transaction, either of them
+// can be nil to leave it untouched. On a standalone MongoDB, which has no transactions, the writes are applied
+// one after the other.
+func (r *MongoRepository) ImportCampaign(ctx context.Context, campaign *models.Campaign, rule *models.TargetingRule) error {
+	var campaignID string
+	if campaign != nil {
+		campaignID = campaign.CampaignID
+	} else if rule != nil {
+		campaignID = rule.CampaignID
+	}
+
+	write := func(ctx context.Context) error {
+		if campaign != nil {
+			_, err := r.campaignCollection.ReplaceOne(ctx, bson.M{"campaign_id": campaign.CampaignID}, campaign,
+				options.Replace().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+
+		if rule != nil {
+			_, err := r.ruleCollection.ReplaceOne(ctx, bson.M{"campaign_id": rule.CampaignID}, rule,
+				options.Replace().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	err := r.inTransaction(ctx, campaignID, write)
+	if err != nil {
+		r.logger.Error("Error while Importing campaign", "campaignID", campaignID, "Error", err.Error())
+		if mongoUnavailable(err) {
+			return mongoError(err)
+		}
+
+		return &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError,
+			Reason: "Failed to import campaign " + campaignID + ": " + err.Error()}
+	}
+
+	return nil
+}
+
+// inTransaction runs the writes on the campaign in a single transaction. On a standalone MongoDB, which has no
+// transactions, they are applied one after the other.
+func (r *MongoRepository) inTransaction(ctx context.Context, campaignID string, write func(ctx context.Context) error) error {
+	err := r.campaignCollection.Database().Client().UseSession(ctx, func(sessCtx mongo.SessionContext) error {
+		_, err := sessCtx.WithTransaction(sessCtx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+			return nil, write(sessCtx)
+		})
+
+		return err
+	})
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == illegalOperationCode {
+		r.logger.Warn("Transactions are not supported, writing campaign without one", "campaignID", campaignID)
+		return write(ctx)
+	}
+
+	return err
+}
+
+// createDimensionRule matches the campaigns without a rule with values on the dimension, and when the value is
+// specified the ones including one of its values, having an exclude list without them or having patterns, which are
+// evaluated after.
+func createDimensionRule(dimension string, values []string) bson.M {
+	unrestricted := bson.M{
+		"rules": bson.M{
+			"$not": bson.M{
+				"$elemMatch": bson.M{
+					"dimension": dimension,
+					"$or": []bson.M{
+						{"include": bson.M{"$exists": true, "$ne": []string{}}},
+						{"exclude": bson.M{"$exists": true, "$ne": []string{}}},
+					},
+				},
+			},
+		},
+	}
+
+	if len(values) == 0 {
+		return unrestricted
+	}
+
+	return bson.M{
+		"$or": []bson.M{
+			unrestricted,
+			{
+				"rules": bson.M{
+					"$elemMatch": bson.M{
+						"dimension": dimension,
+						"$or": []bson.M{
+							{"include": bson.M{"$regex": helpers.PatternSyntax}},
+							{"exclude": bson.M{"$regex": helpers.PatternSyntax}},
+						},
+					},
+				},
+			},
+			{
+				"rules": bson.M{
+					"$elemMatch": bson.M{
+						"dimension": dimension,
+						"include": bson.M{
+							"$exists": true,
+							"$ne":     []string{},
+							"$in":     values,
+						},
+					},
+				},
+			},
+			{
+				"rules": bson.M{
+					"$elemMatch": bson.M{
+						"dimension": dimension,
+						"exclude": bson.M{
+							"$exists": true,
+							"$ne":     []string{},
+							"$nin":    values,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// FindActiveCampaignsByIDs returns the active campaigns whose schedule includes the current time.
+func (r *MongoRepository) FindActiveCampaignsByIDs(ctx context.Context, campaignIDs []string) (*[]models.Response, error) {
+	now := time.Now()
+	filter := bson.M{
+		"campaign_id": bson.M{"$in": campaignIDs},
+		"status":      constants.StatusActive,
+		"$and": []bson.M{
+			{"$or": []bson.M{{"start_at": nil}, {"start_at": bson.M{"$lte": now}}}},
+			{"$or": []bson.M{{"end_at": nil}, {"end_at": bson.M{"$gt": now}}}},
+		},
+	}
+
+	var campaigns []models.Response
+	cur, err := r.campaignCollection.Find(ctx, filter)
+	if err != nil {
+		r.logger.Error("Error while Fetching campaigns", "Error", err.Error())
+		return nil, mongoError(err)
+	}
+
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var campaign models.Response
+		err := cur.Decode(&campaign)
+		if err != nil {
+			r.logger.Error("Error decoding campaign", "Error", err.Error())
+			continue
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return &campaigns, nil
+}
+
+// watchChanges follows a change stream on the campaigns and rules collections. Events arriving together, like the
+// ones of an import, are observed one by one and followed by a single refresh.
+func (r *MongoRepository) watchChanges(ctx context.Context, observe func(operation string, at time.Time), refresh func()) error {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"ns.coll": bson.M{"$in": []string{
+		r.campaignCollection.Name(), r.ruleCollection.Name()}}}}}}
+
+	stream, err := r.campaignCollection.Database().Watch(ctx, pipeline, options.ChangeStream())
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == changeStreamNotSupportedCode {
+		return errChangesNotSupported
+	}
+
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	// Changes made while the stream was not open are picked up by refreshing once it is.
+	refresh()
+
+	decode := func() {
+		var event struct {
+			OperationType string              `bson:"operationType"`
+			ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+		}
+
+		if err := stream.Decode(&event); err != nil {
+			r.logger.Error("Error decoding change event", "Error", err.Error())
+			return
+		}
+
+		observe(event.OperationType, time.Unix(int64(event.ClusterTime.T), 0))
+	}
+
+	for stream.Next(ctx) {
+		decode()
+
+		for stream.TryNext(ctx) {
+			decode()
+		}
+
+		refresh()
+	}
+
+	return stream.Err()
+}
+
+// NextScheduleBoundary returns the earliest start or end after now of the active campaigns, nil when there is none.
+func (r *MongoRepository) NextScheduleBoundary(ctx context.Context, campaignIDs []string, now time.Time) (*time.Time, error) {
+	filter := bson.M{
+		"campaign_id": bson.M{"$in": campaignIDs},
+		"status":      constants.StatusActive,
+		"$or":         []bson.M{{"start_at": bson.M{"$gt": now}}, {"end_at": bson.M{"$gt": now}}},
+	}
+
+	cur, err := r.campaignCollection.Find(ctx, filter, options.Find().SetProjection(bson.M{"start_at": 1, "end_at": 1}))
+	if err != nil {
+		r.logger.Error("Error while Fetching campaign schedules", "Error", err.Error())
+		return nil, mongoError(err)
+	}
+
+	var campaigns []models.Campaign
+	if err := cur.All(ctx, &campaigns); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return nextBoundary(campaigns, now), nil
+}
+
+// mongoUnavailable tells whether the error comes from MongoDB not being reachable, rather than from the operation.
+func mongoUnavailable(err error) bool {
+	var selectionErr topology.ServerSelectionError
+
+	return mongo.IsNetworkError(err) || mongo.IsTimeout(err) || errors.As(err, &selectionErr) ||
+		errors.Is(err, mongo.ErrClientDisconnected)
+}
+
+func mongoError(err error) error {
+	if mongoUnavailable(err) {
+		return unavailableError("MongoDB", err)
+	}
+
+	return &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError, Reason: err.Error()}
+}
@@ -0,0 +1,163 @@
+package stores
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// pacingAllowance is the share of the daily goal a campaign may be ahead of an even pace before it is throttled, an
+// hour's worth so that the start of the day isn't held back.
+const pacingAllowance = 1.0 / 24
+
+// dailyCountTTL keeps the count of a day until every time zone is past it.
+const dailyCountTTL = 48 * time.Hour
+
+// goalCount is what is delivered of a campaign with a goal, today being in its timezone.
+type goalCount struct {
+	total   int64
+	today   int64
+	elapsed float64
+}
+
+// goalKeys count the deliveries of the campaign in total and on the local day.
+func goalKeys(campaignID string, local time.Time) (string, string) {
+	return "goal:" + campaignID + ":total", "goal:" + campaignID + ":" + local.Format(time.DateOnly)
+}
+
+// campaignTime returns now in the timezone of the campaign, UTC when it has none or an unknown one.
+func campaignTime(timezone string, now time.Time) time.Time {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	return now.In(location)
+}
+
+// dayElapsed returns the elapsed share of the local day, days changing their clocks being longer or shorter.
+func dayElapsed(local time.Time) float64 {
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	end := start.AddDate(0, 0, 1)
+
+	return float64(local.Sub(start)) / float64(end.Sub(start))
+}
+
+// pace returns the state of the goal, the chance of delivering the campaign and the deliveries expected today by an
+// even pace. A campaign ahead of the pace by more than the allowance is delivered with the chance of falling back to
+// it.
+func pace(goal *models.ImpressionGoal, count goalCount) (string, float64, int64) {
+	if goal.Total > 0 && count.total >= goal.Total {
+		return constants.PacingGoalReached, 0, 0
+	}
+
+	if goal.Daily == 0 {
+		return constants.PacingOnPace, 1, 0
+	}
+
+	expected := float64(goal.Daily) * count.elapsed
+	if count.today >= goal.Daily {
+		return constants.PacingDailyGoalReached, 0, int64(expected)
+	}
+
+	allowed := allowedToday(goal, count.elapsed)
+	if float64(count.today) < allowed {
+		return constants.PacingOnPace, 1, int64(expected)
+	}
+
+	return constants.PacingAhead, allowed / float64(count.today), int64(expected)
+}
+
+// allowedToday is how many deliveries the daily goal allows by now without throttling, the even pace and the
+// allowance.
+func allowedToday(goal *models.ImpressionGoal, elapsed float64) float64 {
+	return float64(goal.Daily) * (elapsed + pacingAllowance)
+}
+
+// goalCounts reads the counts of the campaigns with a goal, keyed by their position.
+func (s *Store) goalCounts(ctx context.Context, campaigns []models.Response, now time.Time) (map[int]goalCount, error) {
+	counts := make(map[int]goalCount)
+
+	var keys []string
+	var positions []int
+	for i, campaign := range campaigns {
+		if campaign.ImpressionGoal == nil {
+			continue
+		}
+
+		local := campaignTime(campaign.Timezone, now)
+		total, daily := goalKeys(campaign.CampaignID, local)
+
+		keys = append(keys, total, daily)
+		positions = append(positions, i)
+		counts[i] = goalCount{elapsed: dayElapsed(local)}
+	}
+
+	if len(keys) == 0 {
+		return counts, nil
+	}
+
+	values, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range positions {
+		count := counts[i]
+		count.total, count.today = countValue(values[2*j]), countValue(values[2*j+1])
+		counts[i] = count
+	}
+
+	return counts, nil
+}
+
+// countValue reads a count of MGET, nil when the key doesn't exist yet.
+func countValue(value interface{}) int64 {
+	text, ok := value.(string)
+	if !ok {
+		return 0
+	}
+
+	count, _ := strconv.ParseInt(text, 10, 64)
+
+	return count
+}
+
+// Pacing returns the state of the goal of each campaign, untracked ones without Redis.
+func (s *Store) Pacing(ctx context.Context, campaigns []models.Campaign, now time.Time) ([]models.Pacing, error) {
+	responses := make([]models.Response, len(campaigns))
+	for i, campaign := range campaigns {
+		responses[i] = response(campaign)
+	}
+
+	var counts map[int]goalCount
+	if s.redisClient != nil {
+		var err error
+		if counts, err = s.goalCounts(ctx, responses, now); err != nil {
+			s.logger.Error("Error while Counting deliveries", "Error", err.Error())
+			return nil, unavailableError("Redis", err)
+		}
+	}
+
+	pacing := make([]models.Pacing, 0, len(campaigns))
+	for i, campaign := range responses {
+		if campaign.ImpressionGoal == nil {
+			continue
+		}
+
+		state := models.Pacing{CampaignID: campaign.CampaignID, TotalGoal: campaign.ImpressionGoal.Total,
+			DailyGoal: campaign.ImpressionGoal.Daily, State: constants.PacingUntracked, Probability: 1}
+
+		if count, ok := counts[i]; ok {
+			state.DeliveredTotal, state.DeliveredToday = count.total, count.today
+			state.State, state.Probability, state.ExpectedToday = pace(campaign.ImpressionGoal, count)
+		}
+
+		pacing = append(pacing, state)
+	}
+
+	return pacing, nil
+}
@@ -0,0 +1,156 @@
+package stores
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+func TestPace(t *testing.T) {
+	tests := []struct {
+		name                string
+		goal                models.ImpressionGoal
+		count               goalCount
+		expectedState       string
+		expectedProbability float64
+		expectedToday       int64
+	}{
+		{
+			name:                "total goal reached",
+			goal:                models.ImpressionGoal{Total: 1000, Daily: 240},
+			count:               goalCount{total: 1000, today: 10, elapsed: 0.5},
+			expectedState:       "goal_reached",
+			expectedProbability: 0,
+		},
+		{
+			name:                "total goal only",
+			goal:                models.ImpressionGoal{Total: 1000},
+			count:               goalCount{total: 999, today: 999, elapsed: 0.1},
+			expectedState:       "on_pace",
+			expectedProbability: 1,
+		},
+		{
+			name:                "daily goal reached",
+			goal:                models.ImpressionGoal{Daily: 240},
+			count:               goalCount{today: 240, elapsed: 0.5},
+			expectedState:       "daily_goal_reached",
+			expectedProbability: 0,
+			expectedToday:       120,
+		},
+		{
+			name:                "behind the pace",
+			goal:                models.ImpressionGoal{Total: 1000, Daily: 240},
+			count:               goalCount{total: 100, today: 100, elapsed: 0.5},
+			expectedState:       "on_pace",
+			expectedProbability: 1,
+			expectedToday:       120,
+		},
+		{
+			name:                "within the allowance at the start of the day",
+			goal:                models.ImpressionGoal{Daily: 240},
+			count:               goalCount{today: 9},
+			expectedState:       "on_pace",
+			expectedProbability: 1,
+		},
+		{
+			name:                "ahead of the pace",
+			goal:                models.ImpressionGoal{Daily: 240},
+			count:               goalCount{today: 200, elapsed: 0.5},
+			expectedState:       "ahead",
+			expectedProbability: 0.65,
+			expectedToday:       120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, probability, expected := pace(&tt.goal, tt.count)
+
+			assert.Equal(t, tt.expectedState, state)
+			assert.InDelta(t, tt.expectedProbability, probability, 1e-9)
+			assert.Equal(t, tt.expectedToday, expected)
+		})
+	}
+}
+
+func TestDayElapsed(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 0, 30, 0, 0, time.UTC)
+
+	assert.InDelta(t, 0.25, dayElapsed(campaignTime("Asia/Kolkata", now)), 1e-9)
+	assert.InDelta(t, 0.5/24, dayElapsed(campaignTime("", now)), 1e-9)
+	assert.Equal(t, time.UTC, campaignTime("Mars/Olympus", now).Location())
+
+	// The daily count is keyed by the local day, the day before in UTC.
+	_, daily := goalKeys("spotify", time.Date(2026, 3, 1, 1, 0, 0, 0, kolkata))
+	assert.Equal(t, "goal:spotify:2026-03-01", daily)
+}
+
+func TestStore_Pacing(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+
+	campaigns := []models.Campaign{{CampaignID: "spotify", ImpressionGoal: &models.ImpressionGoal{Total: 1000}},
+		{CampaignID: "zepto"}}
+
+	// Without Redis the goals can't be counted, nor paced.
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+	pacing, err := store.Pacing(context.Background(), campaigns, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Pacing{{CampaignID: "spotify", TotalGoal: 1000, Probability: 1, State: "untracked"}}, pacing)
+
+	store = New(repo, redisClient, helpers.InitializeLogger(), cacheHit, cacheMiss)
+	_, err = store.Pacing(context.Background(), campaigns, time.Now())
+	assert.Equal(t, http.StatusServiceUnavailable, err.(*helpers.Error).StatusCode)
+}
+
+func TestStore_ImpressionGoalWithRedisDown(t *testing.T) {
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	store := New(repo, redisClient, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	duolingo, err := store.GetCampaign(context.Background(), "duolingo")
+	require.NoError(t, err)
+
+	duolingo.ImpressionGoal = &models.ImpressionGoal{Total: 1}
+	require.NoError(t, store.UpdateCampaign(context.Background(), duolingo))
+
+	_, err = store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+
+	dimensions := &models.Dimension{APPID: "com.whatsapp", OS: "ios", Country: "in"}
+
+	campaigns, err := store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	require.Len(t, *campaigns, 2)
+
+	// Deliveries can't be counted, so the campaign with a total goal isn't delivered rather than overshooting it.
+	delivered := store.ReserveDeliveries(context.Background(), "", *campaigns, 0)
+	require.Len(t, delivered, 1)
+	assert.NotEqual(t, "duolingo", delivered[0].CampaignID)
+
+	// Daily goals don't stop delivery.
+	(*campaigns)[0].ImpressionGoal = &models.ImpressionGoal{Daily: 1}
+	(*campaigns)[1].ImpressionGoal = &models.ImpressionGoal{Daily: 1}
+	assert.Len(t, store.ReserveDeliveries(context.Background(), "", *campaigns, 0), 2)
+}
@@ -0,0 +1,606 @@
+package stores
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// campaignColumns are in the order of campaignFields and campaignValues.
+const campaignColumns = "campaign_id, name, image, cta, status, start_at, end_at, timezone, priority, weight, frequency_cap," +
+	" impression_goal"
+
+// migrationLockID serializes the migrations of instances starting together.
+const migrationLockID = 7201001
+
+// matchRulesQuery selects the campaigns whose rules may match the values of the queried dimensions given as array
+// parameters in that order, with the same semantics as the MongoDB filter, along with their rules as JSON and their
+// expression to be evaluated.
+var matchRulesQuery = "SELECT t.campaign_id, t.expression, (SELECT jsonb_agg(jsonb_build_object(" +
+	"'dimension', r.dimension, 'include', r.include, 'exclude', r.exclude, 'windows', r.windows) ORDER BY r.position)" +
+	" FROM rules r WHERE r.campaign_id = t.campaign_id) FROM targeting_rules t WHERE " + dimensionConditions()
+
+func dimensionConditions() string {
+	var conditions []string
+	for i, dimension := range queriedDimensions() {
+		conditions = append(conditions, dimensionCondition(dimension, i+1))
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// PostgresRepository stores the campaigns and their targeting rules in PostgreSQL.
+type PostgresRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+func NewPostgresRepository(db *sql.DB, logger *slog.Logger) *PostgresRepository {
+	return &PostgresRepository{db: db, logger: logger}
+}
+
+func (r *PostgresRepository) Backend() string {
+	return constants.BackendPostgres
+}
+
+func (r *PostgresRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// dimensionCondition matches when the campaign has no rule for the dimension, includes one of the values, has a
+// non-empty exclude list without them or has patterns, which are evaluated after. Without values only the first
+// matches.
+func dimensionCondition(dimension string, param int) string {
+	rule := "SELECT 1 FROM rules r WHERE r.campaign_id = t.campaign_id AND r.dimension = '" + dimension + "'"
+
+	return fmt.Sprintf("(NOT EXISTS (%[1]s AND (cardinality(r.include) > 0 OR cardinality(r.exclude) > 0))"+
+		" OR cardinality($%[2]d::TEXT[]) > 0 AND (EXISTS (%[1]s AND r.include && $%[2]d::TEXT[])"+
+		" OR EXISTS (%[1]s AND cardinality(r.exclude) > 0 AND NOT r.exclude && $%[2]d::TEXT[])"+
+		" OR EXISTS (%[1]s AND EXISTS (SELECT 1 FROM unnest(r.include || r.exclude) v WHERE v ~ '%[3]s'))))",
+		rule, param, helpers.PatternSyntax)
+}
+
+// Migrate applies the migrations not applied yet, in the order of their file names.
+func (r *PostgresRepository) Migrate(ctx context.Context) error {
+	files, err := fs.Glob(postgresMigrations, "migrations/postgres/*.sql")
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(files)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY, applied_at TIMESTAMPTZ NOT NULL DEFAULT now())`)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		version := strings.TrimSuffix(file[strings.LastIndex(file, "/")+1:], ".sql")
+
+		var applied bool
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied)
+		if err != nil {
+			return err
+		}
+
+		if applied {
+			continue
+		}
+
+		migration, err := postgresMigrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, string(migration)); err != nil {
+			return fmt.Errorf("migration %s: %w", version, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+			return err
+		}
+
+		r.logger.Info("Applied migration", "version", version)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresRepository) MatchRules(ctx context.Context, dimensions *models.Dimension) ([]string, error) {
+	var values []interface{}
+	for _, dimension := range queriedDimensions() {
+		values = append(values, pq.Array(queriedValues(dimension, dimensionValue(dimensions, dimension))))
+	}
+
+	rows, err := r.db.QueryContext(ctx, matchRulesQuery, values...)
+	if err != nil {
+		r.logger.Error("Error while Fetching Rules", "Error", err.Error())
+		return nil, postgresError(err)
+	}
+	defer rows.Close()
+
+	local := localTime(dimensions, time.Now())
+
+	var campaignIDs []string
+	for rows.Next() {
+		var campaignID, expression string
+		var evaluated []byte
+
+		if err := rows.Scan(&campaignID, &expression, &evaluated); err != nil {
+			r.logger.Error("Error decoding rule:", "Error", err.Error())
+			continue
+		}
+
+		var rules []models.Rule
+		if evaluated != nil {
+			if err := json.Unmarshal(evaluated, &rules); err != nil {
+				r.logger.Error("Error decoding rule:", "Error", err.Error())
+				continue
+			}
+		}
+
+		if matchRules(rules, expression, dimensions, local) {
+			campaignIDs = append(campaignIDs, campaignID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgresError(err)
+	}
+
+	return campaignIDs, nil
+}
+
+// FindActiveCampaignsByIDs returns the active campaigns whose schedule includes the current time.
+func (r *PostgresRepository) FindActiveCampaignsByIDs(ctx context.Context, campaignIDs []string) (*[]models.Response, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT campaign_id, image, cta, priority, weight, frequency_cap, timezone,
+		impression_goal FROM campaigns
+		WHERE campaign_id = ANY($1) AND status = $2 AND (start_at IS NULL OR start_at <= now())
+		AND (end_at IS NULL OR end_at > now())`,
+		pq.Array(campaignIDs), constants.StatusActive)
+	if err != nil {
+		r.logger.Error("Error while Fetching campaigns", "Error", err.Error())
+		return nil, postgresError(err)
+	}
+	defer rows.Close()
+
+	var campaigns []models.Response
+	for rows.Next() {
+		var campaign models.Response
+		err := rows.Scan(&campaign.CampaignID, &campaign.Image, &campaign.CTA, &campaign.Priority, &campaign.Weight,
+			jsonColumn[models.FrequencyCap]{&campaign.FrequencyCap}, &campaign.Timezone,
+			jsonColumn[models.ImpressionGoal]{&campaign.ImpressionGoal})
+		if err != nil {
+			r.logger.Error("Error decoding campaign", "Error", err.Error())
+			continue
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgresError(err)
+	}
+
+	return &campaigns, nil
+}
+
+func (r *PostgresRepository) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	res, err := r.db.ExecContext(ctx, "INSERT INTO campaigns ("+campaignColumns+")"+
+		" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (campaign_id) DO NOTHING",
+		campaignValues(campaign)...)
+	if err != nil {
+		r.logger.Error("Error while Creating campaign", "campaignID", campaign.CampaignID, "Error", err.Error())
+		return postgresError(err)
+	}
+
+	if inserted, _ := res.RowsAffected(); inserted == 0 {
+		return &helpers.Error{Code: "Entity Already Exists", StatusCode: http.StatusConflict,
+			Reason: "Campaign " + campaign.CampaignID + " already exists"}
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	var campaign models.Campaign
+
+	err := r.db.QueryRowContext(ctx, "SELECT "+campaignColumns+" FROM campaigns WHERE campaign_id = $1", campaignID).
+		Scan(campaignFields(&campaign)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, campaignNotFound(campaignID)
+	}
+
+	if err != nil {
+		r.logger.Error("Error while Fetching campaign", "campaignID", campaignID, "Error", err.Error())
+		return nil, postgresError(err)
+	}
+
+	return &campaign, nil
+}
+
+func (r *PostgresRepository) ListCampaigns(ctx context.Context, status string) ([]models.Campaign, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+campaignColumns+" FROM campaigns WHERE $1 = '' OR status = $1"+
+		" ORDER BY campaign_id", status)
+	if err != nil {
+		r.logger.Error("Error while Fetching campaigns", "Error", err.Error())
+		return nil, postgresError(err)
+	}
+	defer rows.Close()
+
+	campaigns := make([]models.Campaign, 0)
+	for rows.Next() {
+		var campaign models.Campaign
+		if err := rows.Scan(campaignFields(&campaign)...); err != nil {
+			r.logger.Error("Error decoding campaign", "Error", err.Error())
+			continue
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgresError(err)
+	}
+
+	return campaigns, nil
+}
+
+func (r *PostgresRepository) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE campaigns SET name = $2, image = $3, cta = $4, status = $5, start_at = $6,
+		end_at = $7, timezone = $8, priority = $9, weight = $10, frequency_cap = $11, impression_goal = $12
+		WHERE campaign_id = $1`,
+		campaignValues(campaign)...)
+	if err != nil {
+		r.logger.Error("Error while Updating campaign", "campaignID", campaign.CampaignID, "Error", err.Error())
+		return postgresError(err)
+	}
+
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return campaignNotFound(campaign.CampaignID)
+	}
+
+	return nil
+}
+
+// DeleteCampaign removes the campaign along with its targeting rules, as rules of a deleted campaign can never match.
+func (r *PostgresRepository) DeleteCampaign(ctx context.Context, campaignID string) error {
+	err := r.inTransaction(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, "DELETE FROM campaigns WHERE campaign_id = $1", campaignID)
+		if err != nil {
+			return err
+		}
+
+		if deleted, _ := res.RowsAffected(); deleted == 0 {
+			return campaignNotFound(campaignID)
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM targeting_rules WHERE campaign_id = $1", campaignID)
+
+		return err
+	})
+
+	return r.writeError(err, "Error while Deleting campaign", campaignID)
+}
+
+func (r *PostgresRepository) GetRules(ctx context.Context, campaignID string) (*models.TargetingRule, error) {
+	rules, err := r.queryRules(ctx, "WHERE t.campaign_id = $1", campaignID)
+	if err != nil {
+		r.logger.Error("Error while Fetching Rules", "campaignID", campaignID, "Error", err.Error())
+		return nil, postgresError(err)
+	}
+
+	if len(rules) == 0 {
+		return nil, rulesNotFound(campaignID)
+	}
+
+	return &rules[0], nil
+}
+
+func (r *PostgresRepository) SaveRules(ctx context.Context, rule *models.TargetingRule) error {
+	err := r.inTransaction(ctx, func(tx *sql.Tx) error {
+		return replaceRules(ctx, tx, rule)
+	})
+
+	return r.writeError(err, "Error while Saving Rules", rule.CampaignID)
+}
+
+func (r *PostgresRepository) DeleteRules(ctx context.Context, campaignID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM targeting_rules WHERE campaign_id = $1", campaignID)
+	if err != nil {
+		r.logger.Error("Error while Deleting Rules", "campaignID", campaignID, "Error", err.Error())
+		return postgresError(err)
+	}
+
+	if deleted, _ := res.RowsAffected(); deleted == 0 {
+		return rulesNotFound(campaignID)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) ListRules(ctx context.Context) ([]models.TargetingRule, error) {
+	rules, err := r.queryRules(ctx, "")
+	if err != nil {
+		r.logger.Error("Error while Fetching Rules", "Error", err.Error())
+		return nil, postgresError(err)
+	}
+
+	return rules, nil
+}
+
+// ImportCampaign upserts the campaign and replaces its targeting rules in a single transaction, either of them
+// can be nil to leave it untouched.
+func (r *PostgresRepository) ImportCampaign(ctx context.Context, campaign *models.Campaign, rule *models.TargetingRule) error {
+	var campaignID string
+	if campaign != nil {
+		campaignID = campaign.CampaignID
+	} else if rule != nil {
+		campaignID = rule.CampaignID
+	}
+
+	err := r.inTransaction(ctx, func(tx *sql.Tx) error {
+		if campaign != nil {
+			_, err := tx.ExecContext(ctx, "INSERT INTO campaigns ("+campaignColumns+")"+
+				" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (campaign_id) DO UPDATE SET"+
+				" name = $2, image = $3, cta = $4, status = $5, start_at = $6, end_at = $7, timezone = $8, priority = $9,"+
+				" weight = $10, frequency_cap = $11, impression_goal = $12",
+				campaignValues(campaign)...)
+			if err != nil {
+				return err
+			}
+		}
+
+		if rule != nil {
+			return replaceRules(ctx, tx, rule)
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("Error while Importing campaign", "campaignID", campaignID, "Error", err.Error())
+		if postgresUnavailable(err) {
+			return postgresError(err)
+		}
+
+		return &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError,
+			Reason: "Failed to import campaign " + campaignID + ": " + err.Error()}
+	}
+
+	return nil
+}
+
+// NextScheduleBoundary returns the earliest start or end after now of the active campaigns, nil when there is none.
+func (r *PostgresRepository) NextScheduleBoundary(ctx context.Context, campaignIDs []string, now time.Time) (*time.Time, error) {
+	var next sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, `SELECT min(boundary) FROM campaigns,
+		LATERAL (VALUES (start_at), (end_at)) AS boundaries (boundary)
+		WHERE campaign_id = ANY($1) AND status = $2 AND boundary > $3`,
+		pq.Array(campaignIDs), constants.StatusActive, now).Scan(&next)
+	if err != nil {
+		r.logger.Error("Error while Fetching campaign schedules", "Error", err.Error())
+		return nil, postgresError(err)
+	}
+
+	if !next.Valid {
+		return nil, nil
+	}
+
+	return &next.Time, nil
+}
+
+// queryRules reads the targeting rules filtered by the where clause, grouped by campaign in campaign_id order.
+func (r *PostgresRepository) queryRules(ctx context.Context, where string, args ...interface{}) ([]models.TargetingRule, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT t.campaign_id, t.expression, r.dimension, r.include, r.exclude, r.windows FROM targeting_rules t
+		LEFT JOIN rules r ON r.campaign_id = t.campaign_id `+where+` ORDER BY t.campaign_id, r.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]models.TargetingRule, 0)
+	for rows.Next() {
+		var campaignID, expression string
+		var dimension sql.NullString
+		var include, exclude pq.StringArray
+		var windows []models.TimeWindow
+
+		err := rows.Scan(&campaignID, &expression, &dimension, &include, &exclude, (*windowsColumn)(&windows))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rules) == 0 || rules[len(rules)-1].CampaignID != campaignID {
+			rules = append(rules, models.TargetingRule{CampaignID: campaignID, Rules: []models.Rule{},
+				Expression: expression})
+		}
+
+		if dimension.Valid {
+			last := &rules[len(rules)-1]
+			last.Rules = append(last.Rules, models.Rule{Dimension: dimension.String, Include: include, Exclude: exclude,
+				Windows: windows})
+		}
+	}
+
+	return rules, rows.Err()
+}
+
+func (r *PostgresRepository) inTransaction(ctx context.Context, write func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := write(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// writeError passes the errors of the repository through and wraps the ones of the database.
+func (r *PostgresRepository) writeError(err error, message, campaignID string) error {
+	var helperErr *helpers.Error
+	if err == nil || errors.As(err, &helperErr) {
+		return err
+	}
+
+	r.logger.Error(message, "campaignID", campaignID, "Error", err.Error())
+
+	return postgresError(err)
+}
+
+func replaceRules(ctx context.Context, tx *sql.Tx, rule *models.TargetingRule) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO targeting_rules (campaign_id, expression) VALUES ($1, $2)"+
+		" ON CONFLICT (campaign_id) DO UPDATE SET expression = $2", rule.CampaignID, rule.Expression)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM rules WHERE campaign_id = $1", rule.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range rule.Rules {
+		_, err = tx.ExecContext(ctx, `INSERT INTO rules (campaign_id, position, dimension, include, exclude, windows)
+			VALUES ($1, $2, $3, COALESCE($4, '{}'::TEXT[]), COALESCE($5, '{}'::TEXT[]), $6)`,
+			rule.CampaignID, i, r.Dimension, pq.Array(r.Include), pq.Array(r.Exclude), windowsColumn(r.Windows))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// windowsColumn reads and writes the windows of a rule as JSONB, no windows being nil like in MongoDB.
+type windowsColumn []models.TimeWindow
+
+func (w *windowsColumn) Scan(src interface{}) error {
+	data, ok := src.([]byte)
+	if !ok {
+		*w = nil
+		return nil
+	}
+
+	var windows []models.TimeWindow
+	if err := json.Unmarshal(data, &windows); err != nil {
+		return err
+	}
+
+	if len(windows) == 0 {
+		windows = nil
+	}
+
+	*w = windows
+
+	return nil
+}
+
+func (w windowsColumn) Value() (driver.Value, error) {
+	if len(w) == 0 {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal([]models.TimeWindow(w))
+
+	return string(data), err
+}
+
+// jsonColumn reads and writes an optional setting of a campaign, like its frequency cap, as JSONB, NULL when it isn't
+// set.
+type jsonColumn[T any] struct {
+	setting **T
+}
+
+func (c jsonColumn[T]) Scan(src interface{}) error {
+	data, ok := src.([]byte)
+	if !ok {
+		*c.setting = nil
+		return nil
+	}
+
+	var setting T
+	if err := json.Unmarshal(data, &setting); err != nil {
+		return err
+	}
+
+	*c.setting = &setting
+
+	return nil
+}
+
+func (c jsonColumn[T]) Value() (driver.Value, error) {
+	if *c.setting == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(*c.setting)
+
+	return string(data), err
+}
+
+// postgresUnavailable tells whether the error comes from PostgreSQL not being reachable, or not accepting
+// connections yet, rather than from the statement.
+func postgresUnavailable(err error) bool {
+	var netErr net.Error
+	var pqErr *pq.Error
+
+	return errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) ||
+		errors.As(err, &pqErr) && (pqErr.Code.Class() == "08" || pqErr.Code == "57P03")
+}
+
+func postgresError(err error) error {
+	if postgresUnavailable(err) {
+		return unavailableError("PostgreSQL", err)
+	}
+
+	return &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError, Reason: err.Error()}
+}
+
+func campaignFields(campaign *models.Campaign) []interface{} {
+	return []interface{}{&campaign.CampaignID, &campaign.Name, &campaign.Image, &campaign.CTA, &campaign.Status,
+		&campaign.StartAt, &campaign.EndAt, &campaign.Timezone, &campaign.Priority, &campaign.Weight,
+		jsonColumn[models.FrequencyCap]{&campaign.FrequencyCap},
+		jsonColumn[models.ImpressionGoal]{&campaign.ImpressionGoal}}
+}
+
+func campaignValues(campaign *models.Campaign) []interface{} {
+	return []interface{}{campaign.CampaignID, campaign.Name, campaign.Image, campaign.CTA, campaign.Status,
+		campaign.StartAt, campaign.EndAt, campaign.Timezone, campaign.Priority, campaign.Weight,
+		jsonColumn[models.FrequencyCap]{&campaign.FrequencyCap},
+		jsonColumn[models.ImpressionGoal]{&campaign.ImpressionGoal}}
+}
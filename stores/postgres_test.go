@@ -0,0 +1,70 @@
+package stores
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+)
+
+func setupPostgres(t *testing.T) *PostgresRepository {
+	dsn := os.Getenv("POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_DSN is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	repo := NewPostgresRepository(db, helpers.InitializeLogger())
+	require.NoError(t, repo.Migrate(ctx))
+	require.NoError(t, repo.Migrate(ctx), "migrations are applied once")
+
+	_, err = db.ExecContext(ctx, "TRUNCATE campaigns, targeting_rules CASCADE")
+	require.NoError(t, err)
+
+	campaigns, rules := readTestdata(t)
+	for i := range campaigns {
+		require.NoError(t, repo.ImportCampaign(ctx, &campaigns[i], nil))
+	}
+
+	for i := range rules {
+		require.NoError(t, repo.ImportCampaign(ctx, nil, &rules[i]))
+	}
+
+	return repo
+}
+
+// TestPostgresRepository_MatchRules checks that the SQL query agrees with the targeting index.
+func TestPostgresRepository_MatchRules(t *testing.T) {
+	repo := setupPostgres(t)
+
+	assertMatchesIndex(t, repo)
+}
+
+func TestPostgresRepository_Rules(t *testing.T) {
+	repo := setupPostgres(t)
+	ctx := context.Background()
+
+	_, expected := readTestdata(t)
+
+	rules, err := repo.ListRules(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, expected, rules)
+
+	rule, err := repo.GetRules(ctx, expected[0].CampaignID)
+	require.NoError(t, err)
+	assert.Equal(t, expected[0], *rule)
+
+	require.NoError(t, repo.DeleteCampaign(ctx, expected[0].CampaignID))
+
+	_, err = repo.GetRules(ctx, expected[0].CampaignID)
+	assert.Equal(t, rulesNotFound(expected[0].CampaignID), err)
+}
@@ -0,0 +1,34 @@
+package stores
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// rankCampaigns orders the campaigns by priority and then weight, higher first, the ties going to the lower campaign
+// id so that every request gets them in the same order.
+func rankCampaigns(campaigns []models.Response) {
+	slices.SortFunc(campaigns, compareRank)
+}
+
+func compareRank(a, b models.Response) int {
+	if a.Priority != b.Priority {
+		return cmp.Compare(b.Priority, a.Priority)
+	}
+
+	if a.Weight != b.Weight {
+		return cmp.Compare(b.Weight, a.Weight)
+	}
+
+	return strings.Compare(a.CampaignID, b.CampaignID)
+}
+
+// response is what is delivered of a campaign.
+func response(campaign models.Campaign) models.Response {
+	return models.Response{CampaignID: campaign.CampaignID, Image: campaign.Image, CTA: campaign.CTA,
+		Priority: campaign.Priority, Weight: campaign.Weight, FrequencyCap: campaign.FrequencyCap,
+		ImpressionGoal: campaign.ImpressionGoal, Timezone: campaign.Timezone}
+}
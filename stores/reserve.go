@@ -0,0 +1,151 @@
+package stores
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// reserveDelivery checks the delivery of a campaign against the frequency cap of the user and the impression goals,
+// and counts it towards both only when it is within them, in one step so that concurrent requests can't go past
+// either. A campaign ahead of its daily pace is delivered when the random draw is below allowed / today, like pace
+// tells. It returns 1 when the delivery is counted.
+//
+// KEYS: the impressions of the user, the total and the daily deliveries of the campaign.
+// ARGV: now and cap window in milliseconds, cap (0 when uncapped), member, total and daily goals (0 when not set),
+// deliveries allowed today, random draw, seconds the daily count is kept.
+var reserveDelivery = redis.NewScript(`
+local now, window, cap = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+if cap > 0 then
+	redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+	if redis.call('ZCARD', KEYS[1]) >= cap then
+		return 0
+	end
+end
+
+local totalGoal, dailyGoal = tonumber(ARGV[5]), tonumber(ARGV[6])
+if totalGoal > 0 or dailyGoal > 0 then
+	local total = tonumber(redis.call('GET', KEYS[2]) or '0')
+	local today = tonumber(redis.call('GET', KEYS[3]) or '0')
+	if totalGoal > 0 and total >= totalGoal then
+		return 0
+	end
+
+	if dailyGoal > 0 then
+		local allowed = tonumber(ARGV[7])
+		if today >= dailyGoal or today >= allowed and tonumber(ARGV[8]) >= allowed / today then
+			return 0
+		end
+	end
+
+	redis.call('INCR', KEYS[2])
+	redis.call('INCR', KEYS[3])
+	redis.call('EXPIRE', KEYS[3], ARGV[9])
+end
+
+if cap > 0 then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	redis.call('PEXPIRE', KEYS[1], window)
+end
+return 1
+`)
+
+// ReserveDeliveries returns the campaigns delivered to the user, in order, the first limit of them when limit isn't 0.
+// Each delivery is counted as it is reserved, towards the frequency cap of the user and the impression goals of the
+// campaign, and a campaign at its cap, past its goal or throttled by its pace is left out, the next ones taking its
+// place.
+func (s *Store) ReserveDeliveries(ctx context.Context, userID string, campaigns []models.Response,
+	limit int) []models.Response {
+	if limit <= 0 || limit > len(campaigns) {
+		limit = len(campaigns)
+	}
+
+	now := time.Now()
+	delivered := make([]models.Response, 0, limit)
+
+	for len(campaigns) > 0 && len(delivered) < limit {
+		batch := campaigns[:min(limit-len(delivered), len(campaigns))]
+		campaigns = campaigns[len(batch):]
+
+		for i, reserved := range s.reserve(ctx, userID, batch, now) {
+			if reserved {
+				delivered = append(delivered, batch[i])
+			}
+		}
+	}
+
+	return delivered
+}
+
+// reserve reserves the campaigns of the batch in one round trip, and tells which of them can be delivered. When Redis
+// fails, caps and daily goals are skipped as missing counts shouldn't stop delivery, but campaigns with a total goal
+// aren't delivered so that the goal is never overshot. Without Redis neither caps nor goals apply.
+func (s *Store) reserve(ctx context.Context, userID string, batch []models.Response, now time.Time) []bool {
+	reserved := make([]bool, len(batch))
+	for i := range reserved {
+		reserved[i] = true
+	}
+
+	if s.redisClient == nil {
+		return reserved
+	}
+
+	results := make(map[int]*redis.Cmd)
+
+	_, err := s.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, campaign := range batch {
+			var window time.Duration
+			if userID != "" {
+				window = capWindow(campaign.FrequencyCap)
+			}
+
+			goal := campaign.ImpressionGoal
+			if window == 0 && goal == nil {
+				continue
+			}
+
+			impressions, member := 0, ""
+			if window > 0 {
+				// The member only has to be unique, concurrent deliveries landing on the same millisecond.
+				impressions, member = campaign.FrequencyCap.Impressions,
+					strconv.FormatInt(now.UnixNano(), 36)+":"+strconv.FormatInt(rand.Int63(), 36)
+			}
+
+			var totalGoal, dailyGoal int64
+			var allowed float64
+			local := campaignTime(campaign.Timezone, now)
+			if goal != nil {
+				totalGoal, dailyGoal, allowed = goal.Total, goal.Daily, allowedToday(goal, dayElapsed(local))
+			}
+
+			total, daily := goalKeys(campaign.CampaignID, local)
+			results[i] = reserveDelivery.Eval(ctx, pipe, []string{frequencyKey(campaign.CampaignID, userID), total, daily},
+				now.UnixMilli(), window.Milliseconds(), impressions, member, totalGoal, dailyGoal, allowed, rand.Float64(),
+				int64(dailyCountTTL.Seconds()))
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Error while Reserving deliveries, frequency caps and daily goals not applied", "userID", userID,
+			"Error", err.Error())
+
+		for i, campaign := range batch {
+			reserved[i] = campaign.ImpressionGoal == nil || campaign.ImpressionGoal.Total == 0
+		}
+
+		return reserved
+	}
+
+	for i, result := range results {
+		counted, _ := result.Int64()
+		reserved[i] = counted == 1
+	}
+
+	return reserved
+}
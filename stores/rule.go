@@ -0,0 +1,27 @@
+package stores
+
+import (
+	"context"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+func (s *Store) SaveRules(ctx context.Context, rule *models.TargetingRule) error {
+	if err := s.Repository.SaveRules(ctx, rule); err != nil {
+		return err
+	}
+
+	s.rulesChanged(ctx, rule.CampaignID)
+
+	return nil
+}
+
+func (s *Store) DeleteRules(ctx context.Context, campaignID string) error {
+	if err := s.Repository.DeleteRules(ctx, campaignID); err != nil {
+		return err
+	}
+
+	s.campaignsChanged(ctx, campaignID)
+
+	return nil
+}
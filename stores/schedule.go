@@ -0,0 +1,40 @@
+package stores
+
+import (
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// maxCacheTTL bounds how long a delivery response is cached when no schedule boundary comes earlier.
+const maxCacheTTL = 10 * time.Hour
+
+// inSchedule tells whether now is within [start, end), an open bound is always satisfied.
+func inSchedule(start, end *time.Time, now time.Time) bool {
+	return (start == nil || !now.Before(*start)) && (end == nil || now.Before(*end))
+}
+
+// nextBoundary returns the earliest start or end of the campaigns after now, nil when there is none.
+func nextBoundary(campaigns []models.Campaign, now time.Time) *time.Time {
+	var next *time.Time
+
+	for _, campaign := range campaigns {
+		for _, boundary := range []*time.Time{campaign.StartAt, campaign.EndAt} {
+			if boundary != nil && boundary.After(now) && (next == nil || boundary.Before(*next)) {
+				next = boundary
+			}
+		}
+	}
+
+	return next
+}
+
+// cacheTTL caches a response until the next schedule boundary at the latest, so that campaigns appear and disappear
+// on time.
+func cacheTTL(boundary *time.Time, now time.Time) time.Duration {
+	if boundary == nil || boundary.Sub(now) > maxCacheTTL {
+		return maxCacheTTL
+	}
+
+	return boundary.Sub(now)
+}
@@ -0,0 +1,70 @@
+package stores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+func TestSchedule(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	repo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	for campaignID, schedule := range map[string][2]*time.Time{"spotify": {&future, nil}, "netflix": {&past, &future}} {
+		campaign, err := repo.GetCampaign(ctx, campaignID)
+		require.NoError(t, err)
+
+		campaign.StartAt, campaign.EndAt = schedule[0], schedule[1]
+		require.NoError(t, repo.UpdateCampaign(ctx, campaign))
+	}
+
+	dimensions := &models.Dimension{APPID: "com.zhiliaoapp.musically", OS: "ios", Country: "us"}
+
+	campaignIDs, err := repo.MatchRules(ctx, dimensions)
+	require.NoError(t, err)
+
+	campaigns, err := repo.FindActiveCampaignsByIDs(ctx, campaignIDs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"netflix"}, responseIDs(*campaigns))
+
+	boundary, err := repo.NextScheduleBoundary(ctx, campaignIDs, now)
+	require.NoError(t, err)
+	assert.Equal(t, &future, boundary)
+
+	all, err := repo.ListCampaigns(ctx, "")
+	require.NoError(t, err)
+
+	rules, err := repo.ListRules(ctx)
+	require.NoError(t, err)
+
+	snapshot := buildIndex(all, rules)
+	assert.Equal(t, []string{"netflix"}, responseIDs(snapshot.match(dimensions, now)))
+	assert.Equal(t, []string{"spotify"}, responseIDs(snapshot.match(dimensions, future.Add(time.Minute))))
+}
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Now()
+	soon, later := now.Add(time.Minute), now.Add(maxCacheTTL+time.Hour)
+
+	assert.Equal(t, maxCacheTTL, cacheTTL(nil, now))
+	assert.Equal(t, time.Minute, cacheTTL(&soon, now))
+	assert.Equal(t, maxCacheTTL, cacheTTL(&later, now))
+}
+
+func responseIDs(campaigns []models.Response) []string {
+	var campaignIDs []string
+	for _, campaign := range campaigns {
+		campaignIDs = append(campaignIDs, campaign.CampaignID)
+	}
+
+	return campaignIDs
+}
@@ -3,74 +3,116 @@ package stores
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/prometheus/client_golang/prometheus"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/Durga-Chikkala/delivery-service/constants"
 	"github.com/Durga-Chikkala/delivery-service/helpers"
 	"github.com/Durga-Chikkala/delivery-service/models"
 )
 
+// Store serves delivery from the targeting index and Redis, on top of the Repository holding campaigns and rules.
 type Store struct {
-	redisClient        *redis.Client
-	logger             *slog.Logger
-	ruleCollection     *mongo.Collection
-	campaignCollection *mongo.Collection
-	cacheHit           *prometheus.CounterVec
-	cacheMiss          *prometheus.CounterVec
+	Repository
+	redisClient *redis.Client
+	logger      *slog.Logger
+	cacheHit    *prometheus.CounterVec
+	cacheMiss   *prometheus.CounterVec
+	index       *targetingIndex
+	nonces      *nonceSet
 }
 
-func New(db *mongo.Database, redisClient *redis.Client, logger *slog.Logger, cacheHit, cacheMiss *prometheus.CounterVec) Store {
-	ruleCollection := db.Collection("rules")
-	campaignCollection := db.Collection("campaigns")
+func New(repo Repository, redisClient *redis.Client, logger *slog.Logger, cacheHit, cacheMiss *prometheus.CounterVec) Store {
+	return Store{Repository: repo, redisClient: redisClient, logger: logger, cacheHit: cacheHit, cacheMiss: cacheMiss,
+		index: &targetingIndex{}, nonces: newNonceSet()}
+}
+
+// NewRepository returns the repository of the configured STORE_BACKEND, migrating its schema where it has one.
+func NewRepository(ctx context.Context, helper *models.Helpers) (Repository, error) {
+	switch helper.StoreBackend {
+	case constants.BackendPostgres:
+		if helper.SQL == nil {
+			return nil, errors.New("PostgreSQL is not configured")
+		}
+
+		repo := NewPostgresRepository(helper.SQL, helper.Logger)
 
-	return Store{ruleCollection: ruleCollection, campaignCollection: campaignCollection, redisClient: redisClient,
-		logger: logger, cacheHit: cacheHit, cacheMiss: cacheMiss}
+		return repo, repo.Migrate(ctx)
+	case constants.BackendMongo:
+		if helper.DB == nil {
+			return nil, errors.New("MongoDB is not configured")
+		}
+
+		return NewMongoRepository(helper.DB, helper.Logger), nil
+	case constants.BackendFile:
+		return NewFileRepository(helper.FileStorePath)
+	default:
+		return nil, errors.New("unknown store backend " + helper.StoreBackend)
+	}
 }
 
+// Get serves the ranked campaigns from the targeting index once it is loaded, and from Redis and the repository otherwise.
+// Frequency caps and impression goals change with every delivery, they are applied as the deliveries are reserved.
 func (s *Store) Get(ctx *gin.Context, dimensions *models.Dimension) (*[]models.Response, error) {
-	cacheKey := generateCacheKey(dimensions.APPID, dimensions.OS, dimensions.Country)
+	return s.match(ctx, dimensions, time.Now())
+}
 
-	cachedCampaigns, err := s.redisClient.Get(ctx, cacheKey).Result()
-	if err == redis.Nil {
-		s.cacheMiss.WithLabelValues("campaigns").Inc()
-	} else if err == nil {
-		s.cacheHit.WithLabelValues("campaigns").Inc()
-		var campaigns []models.Response
-		if err := json.Unmarshal([]byte(cachedCampaigns), &campaigns); err == nil {
-			return &campaigns, nil
+// match returns the campaigns matching the dimensions.
+func (s *Store) match(ctx context.Context, dimensions *models.Dimension, now time.Time) (*[]models.Response, error) {
+	if snapshot := s.index.load(); snapshot != nil {
+		s.cacheHit.WithLabelValues("index").Inc()
+
+		campaigns := snapshot.match(dimensions, now)
+		if len(campaigns) == 0 {
+			return nil, nil
 		}
-	}
 
-	ruleFilter := bson.M{
-		"$and": []bson.M{
-			createDimensionRule("app", dimensions.APPID),
-			createDimensionRule("country", dimensions.Country),
-			createDimensionRule("os", dimensions.OS),
-		},
+		return &campaigns, nil
 	}
 
-	cur, err := s.ruleCollection.Find(ctx, ruleFilter)
-	if err != nil {
-		s.logger.Error("Error while Fetching Rules", "Error", err.Error())
-		return nil, &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError, Reason: err.Error()}
+	// Daypart rules make the response depend on the local hour, so it is part of the key.
+	local := localTime(dimensions, now)
+
+	// Without Redis, the cache is disabled.
+	cached := s.redisClient != nil
+	var cacheKey string
+	if cached {
+		version, err := s.rulesVersion(ctx)
+		cached = err == nil
+		cacheKey = generateCacheKey(dimensions, helpers.Daypart(local), version)
 	}
-	defer cur.Close(ctx)
 
-	var campaignIDs []string
-	for cur.Next(ctx) {
-		var rule models.TargetingRule
-		if err := cur.Decode(&rule); err != nil {
-			s.logger.Error("Error decoding rule:", "Error", err.Error())
-			continue
+	if cached {
+		cachedCampaigns, err := s.redisClient.Get(ctx, cacheKey).Result()
+		if err == redis.Nil {
+			s.cacheMiss.WithLabelValues("campaigns").Inc()
+		} else if err == nil {
+			s.cacheHit.WithLabelValues("campaigns").Inc()
+			var cached []cachedCampaign
+			if err := json.Unmarshal([]byte(cachedCampaigns), &cached); err == nil {
+				campaigns := make([]models.Response, len(cached))
+				for i, campaign := range cached {
+					campaigns[i] = campaign.Response
+					campaigns[i].FrequencyCap = campaign.FrequencyCap
+					campaigns[i].ImpressionGoal = campaign.ImpressionGoal
+					campaigns[i].Timezone = campaign.Timezone
+				}
+
+				return &campaigns, nil
+			}
 		}
-		campaignIDs = append(campaignIDs, rule.CampaignID)
+	}
+
+	campaignIDs, err := s.MatchRules(ctx, dimensions)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(campaignIDs) == 0 {
@@ -82,102 +124,100 @@ func (s *Store) Get(ctx *gin.Context, dimensions *models.Dimension) (*[]models.R
 		return nil, err
 	}
 
-	campaignJSON, err := json.Marshal(freshCampaigns)
-	if err == nil {
-		s.redisClient.Set(ctx, cacheKey, campaignJSON, 10*time.Hour)
+	if freshCampaigns != nil {
+		rankCampaigns(*freshCampaigns)
+	}
 
-		for _, campaignID := range campaignIDs {
-			err = s.redisClient.SAdd(ctx, "campaign:"+campaignID+":keys", cacheKey).Err()
-			if err != nil {
-				s.logger.Error("Error storing cache key in Redis set", "Error", err.Error())
-			}
-		}
+	if cached {
+		s.cache(ctx, cacheKey, campaignIDs, freshCampaigns, local)
 	}
 
 	return freshCampaigns, nil
 }
 
-func generateCacheKey(appID, os, country string) string {
-	return "campaign:" + appID + ":" + os + ":" + country
-}
+// cache stores the response until the next schedule boundary of the matched campaigns or the end of the local hour
+// at the latest, and records the key under each campaign for the invalidation.
+func (s *Store) cache(ctx context.Context, cacheKey string, campaignIDs []string, campaigns *[]models.Response,
+	local time.Time) {
+	now := time.Now()
 
-func createDimensionRule(dimension, value string) bson.M {
-	return bson.M{
-		"$or": []bson.M{
-			{
-				"rules": bson.M{
-					"$not": bson.M{
-						"$elemMatch": bson.M{
-							"dimension": dimension,
-							"$or": []bson.M{
-								{"include": bson.M{"$exists": true, "$ne": []string{}}},
-								{"exclude": bson.M{"$exists": true, "$ne": []string{}}},
-							},
-						},
-					},
-				},
-			},
-			{
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"dimension": dimension,
-						"include": bson.M{
-							"$exists": true,
-							"$ne":     []string{},
-							"$in":     []string{value},
-						},
-					},
-				},
-			},
-			{
-				"rules": bson.M{
-					"$elemMatch": bson.M{
-						"dimension": dimension,
-						"exclude": bson.M{
-							"$exists": true,
-							"$ne":     []string{},
-							"$nin":    []string{value},
-						},
-					},
-				},
-			},
-		},
+	boundary, err := s.NextScheduleBoundary(ctx, campaignIDs, now)
+	if err != nil {
+		s.logger.Error("Error while Fetching next schedule boundary, not caching", "Error", err.Error())
+		return
 	}
-}
 
-func (s *Store) FindActiveCampaignsByIDs(ctx context.Context, campaignIDs []string) (*[]models.Response, error) {
-	filter := bson.M{
-		"campaign_id": bson.M{"$in": campaignIDs},
-		"status":      "ACTIVE",
+	if next := nextDaypart(local); boundary == nil || next.Before(*boundary) {
+		boundary = &next
 	}
 
-	var campaigns []models.Response
-	cur, err := s.campaignCollection.Find(ctx, filter)
+	var cached []cachedCampaign
+	if campaigns != nil {
+		cached = make([]cachedCampaign, len(*campaigns))
+		for i, campaign := range *campaigns {
+			cached[i] = cachedCampaign{Response: campaign, FrequencyCap: campaign.FrequencyCap,
+				ImpressionGoal: campaign.ImpressionGoal, Timezone: campaign.Timezone}
+		}
+	}
+
+	campaignJSON, err := json.Marshal(cached)
 	if err != nil {
-		s.logger.Error("Error while Fetching campaigns", "Error", err.Error())
-		return nil, &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError, Reason: err.Error()}
+		return
 	}
 
-	defer cur.Close(ctx)
+	s.redisClient.Set(ctx, cacheKey, campaignJSON, cacheTTL(boundary, now))
 
-	for cur.Next(ctx) {
-		var campaign models.Response
-		err := cur.Decode(&campaign)
+	for _, campaignID := range campaignIDs {
+		err = s.redisClient.SAdd(ctx, "campaign:"+campaignID+":keys", cacheKey).Err()
 		if err != nil {
-			s.logger.Error("Error decoding campaign", "Error", err.Error())
-			continue
+			s.logger.Error("Error storing cache key in Redis set", "Error", err.Error())
 		}
-		campaigns = append(campaigns, campaign)
 	}
+}
+
+// cachedCampaign keeps the frequency cap and the impression goal along with the cached response, so that they can be
+// applied on a hit.
+type cachedCampaign struct {
+	models.Response
+	FrequencyCap   *models.FrequencyCap   `json:"frequency_cap,omitempty"`
+	ImpressionGoal *models.ImpressionGoal `json:"impression_goal,omitempty"`
+	Timezone       string                 `json:"timezone,omitempty"`
+}
+
+// generateCacheKey keys the response by the value of every dimension, unspecified ones included as empty values, and
+// by the version of the rules it was matched with.
+func generateCacheKey(dimensions *models.Dimension, daypart string, version int64) string {
+	return "campaign:v" + strconv.FormatInt(version, 10) + ":" + dimensions.APPID + ":" + dimensions.OS + ":" + dimensions.Country + ":" + dimensions.AppVersion +
+		":" + dimensions.OSVersion + ":" + dimensions.Device + ":" + dimensions.Lang + ":" + daypart
+}
+
+// rulesVersionKey counts the writes of rules. The responses are cached under the version, as rules can make a
+// campaign match dimensions it isn't cached for yet, so every write of rules leaves the cached responses behind.
+const rulesVersionKey = "rules:version"
+
+func (s *Store) rulesVersion(ctx context.Context) (int64, error) {
+	version, err := s.redisClient.Get(ctx, rulesVersionKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+
+	return version, err
+}
 
-	if err := cur.Err(); err != nil {
-		return nil, &helpers.Error{Code: "Internal Server Error", StatusCode: http.StatusInternalServerError, Reason: err.Error()}
+// bumpRulesVersion leaves the cached responses behind, they expire by the end of the hour at the latest.
+func (s *Store) bumpRulesVersion(ctx context.Context) error {
+	if s.redisClient == nil {
+		return nil
 	}
 
-	return &campaigns, nil
+	return s.redisClient.Incr(ctx, rulesVersionKey).Err()
 }
 
 func (s *Store) InvalidateCampaignCache(ctx context.Context, campaignID string) error {
+	if s.redisClient == nil {
+		return nil
+	}
+
 	cacheKeys, err := s.redisClient.SMembers(ctx, "campaign:"+campaignID+":keys").Result()
 	if err != nil {
 		s.logger.Error("Error fetching cache keys from Redis set", "campaignID", campaignID, "Error", err.Error())
@@ -189,15 +229,22 @@ func (s *Store) InvalidateCampaignCache(ctx context.Context, campaignID string)
 		return nil
 	}
 
+	var failed error
 	for _, cacheKey := range cacheKeys {
 		err = s.redisClient.Del(ctx, cacheKey).Err()
 		if err != nil {
 			s.logger.Error("Error deleting cache key", "cacheKey", cacheKey, "Error", err.Error())
+			failed = err
 			continue
 		}
 		s.logger.Info("Cache key invalidated", "cacheKey", cacheKey)
 	}
 
+	// The set is kept until every key is deleted, so that a retry still finds the ones left.
+	if failed != nil {
+		return failed
+	}
+
 	err = s.redisClient.Del(ctx, "campaign:"+campaignID+":keys").Err()
 	if err != nil {
 		s.logger.Error("Error deleting Redis set for campaign", "campaignID", campaignID, "Error", err.Error())
@@ -207,3 +254,16 @@ func (s *Store) InvalidateCampaignCache(ctx context.Context, campaignID string)
 	s.logger.Info("Cache invalidated for campaign", "campaignID", campaignID)
 	return nil
 }
+
+func unavailableError(backend string, err error) error {
+	return &helpers.Error{Code: "Service Unavailable", StatusCode: http.StatusServiceUnavailable,
+		Reason: backend + " is unavailable: " + err.Error()}
+}
+
+func campaignNotFound(campaignID string) error {
+	return &helpers.Error{Code: "Entity Not Found", StatusCode: http.StatusNotFound, Reason: "Campaign " + campaignID + " not found"}
+}
+
+func rulesNotFound(campaignID string) error {
+	return &helpers.Error{Code: "Entity Not Found", StatusCode: http.StatusNotFound, Reason: "Rules for campaign " + campaignID + " not found"}
+}
@@ -2,43 +2,31 @@ package stores
 
 import (
 	"context"
+	"database/sql/driver"
 	"encoding/csv"
 	"log"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 
 	"github.com/Durga-Chikkala/delivery-service/helpers"
 	"github.com/Durga-Chikkala/delivery-service/models"
 )
 
-type TargetingRule struct {
-	CampaignID string `bson:"campaign_id"`
-	Rules      []Rule `bson:"rules"`
-}
-
-type Rule struct {
-	Dimension string   `bson:"dimension"`
-	Include   []string `bson:"include"`
-	Exclude   []string `bson:"exclude"`
-}
-
-type Campaign struct {
-	CampaignID string `bson:"campaign_id"`
-	Name       string `bson:"name"`
-	Image      string `bson:"image"`
-	CTA        string `bson:"cta"`
-	Status     string `bson:"status"`
-}
-
 func insertRules(collection *mongo.Collection) {
 	file, err := os.Open("./testdata/rules.csv")
 	if err != nil {
@@ -56,7 +44,7 @@ func insertRules(collection *mongo.Collection) {
 	var allDimensions = []string{"country", "os", "app"}
 
 	// Map to hold CampaignID -> Rules
-	campaignMap := make(map[string][]Rule)
+	campaignMap := make(map[string][]models.Rule)
 
 	// Parse CSV rows into rules
 	for _, record := range records[1:] { // Skip header
@@ -74,7 +62,7 @@ func insertRules(collection *mongo.Collection) {
 		}
 
 		// Create rule
-		rule := Rule{
+		rule := models.Rule{
 			Dimension: dimension,
 			Include:   include,
 			Exclude:   exclude,
@@ -94,7 +82,7 @@ func insertRules(collection *mongo.Collection) {
 		// For each possible dimension, if it's missing, add an empty rule
 		for _, dimension := range allDimensions {
 			if !existingDimensions[dimension] {
-				emptyRule := Rule{
+				emptyRule := models.Rule{
 					Dimension: dimension,
 					Include:   []string{},
 					Exclude:   []string{},
@@ -109,7 +97,7 @@ func insertRules(collection *mongo.Collection) {
 
 	// Insert each campaign's rules into MongoDB
 	for campaignID, rules := range campaignMap {
-		campaignRule := TargetingRule{
+		campaignRule := models.TargetingRule{
 			CampaignID: campaignID,
 			Rules:      rules,
 		}
@@ -143,7 +131,7 @@ func insertCampaigns(collection *mongo.Collection) {
 			continue
 		}
 
-		campaign := Campaign{
+		campaign := models.Campaign{
 			CampaignID: record[0],
 			Name:       record[1],
 			Image:      record[2],
@@ -166,13 +154,23 @@ func setupStore(t *testing.T) *Store {
 
 	helper := helpers.New()
 
-	store := New(helper.DB, helper.Redis, helper.Logger, helper.Metrics.CacheHits, helper.Metrics.CacheMisses)
-	insertRules(store.ruleCollection)
-	insertCampaigns(store.campaignCollection)
+	repo := NewMongoRepository(helper.DB, helper.Logger)
+	insertRules(repo.ruleCollection)
+	insertCampaigns(repo.campaignCollection)
+
+	store := New(repo, helper.Redis, helper.Logger, helper.Metrics.CacheHits, helper.Metrics.CacheMisses)
 
 	return &store
 }
 
+// testCacheKey keys the response to the dimensions like match does, under the current rules version.
+func testCacheKey(t *testing.T, store *Store, dimensions *models.Dimension) string {
+	version, err := store.rulesVersion(context.Background())
+	require.NoError(t, err)
+
+	return generateCacheKey(dimensions, helpers.Daypart(time.Now().UTC()), version)
+}
+
 func TestStore_Get(t *testing.T) {
 	store := setupStore(t)
 
@@ -205,13 +203,28 @@ func TestStore_Get(t *testing.T) {
 			expectedCampaigns: nil,
 			expectedErr:       nil,
 		},
+		{
+			name:              "No Campaigns Found for the alpha-2 code",
+			dimensions:        &models.Dimension{APPID: "nonExistentApp", OS: "windows", Country: "kp"},
+			cacheData:         "",
+			expectedCampaigns: nil,
+			expectedErr:       nil,
+		},
+		{
+			name:       "Full country names match the rules of their codes",
+			dimensions: &models.Dimension{APPID: "exampleApp", OS: "android", Country: "United States"},
+			cacheData:  "",
+			expectedCampaigns: []models.Response{
+				{CampaignID: "spotify", Image: "https://example.com/images/spotify.png", CTA: "Listen Now"},
+			}, expectedErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.cacheData != "" {
-				store.redisClient.Set(context.Background(), generateCacheKey(tt.dimensions.APPID, tt.dimensions.OS,
-					tt.dimensions.Country), tt.cacheData, 1*time.Second)
+				store.redisClient.Set(context.Background(), testCacheKey(t, store, tt.dimensions),
+					tt.cacheData, 1*time.Second)
 			}
 
 			result, err := store.Get(&gin.Context{}, tt.dimensions)
@@ -226,6 +239,105 @@ func TestStore_Get(t *testing.T) {
 	}
 }
 
+func TestStore_FrequencyCap(t *testing.T) {
+	store := setupStore(t)
+
+	dimensions := &models.Dimension{APPID: "spotify", OS: "iOS", Country: "us", UserID: "device-1"}
+	store.redisClient.Del(context.Background(), frequencyKey("1", dimensions.UserID))
+	store.redisClient.Set(context.Background(), testCacheKey(t, store, dimensions),
+		`[{"cid": "1", "img": "image1.png", "cta": "Download", "frequency_cap": {"impressions": 1, "window": "1h"}}]`,
+		time.Minute)
+
+	result, err := store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	require.Len(t, *result, 1)
+	assert.Equal(t, &models.FrequencyCap{Impressions: 1, Window: "1h"}, (*result)[0].FrequencyCap)
+
+	assert.Len(t, store.ReserveDeliveries(context.Background(), dimensions.UserID, *result, 0), 1)
+	assert.Empty(t, store.ReserveDeliveries(context.Background(), dimensions.UserID, *result, 0))
+
+	// Other users aren't capped by the impressions of the first one.
+	assert.Len(t, store.ReserveDeliveries(context.Background(), "device-2", *result, 0), 1)
+
+	// Concurrent requests of a user don't go past the cap.
+	capped := []models.Response{{CampaignID: "1", FrequencyCap: &models.FrequencyCap{Impressions: 3, Window: "1h"}}}
+	store.redisClient.Del(context.Background(), frequencyKey("1", "device-3"))
+
+	var delivered atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivered.Add(int32(len(store.ReserveDeliveries(context.Background(), "device-3", capped, 0))))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), delivered.Load())
+}
+
+func TestStore_ImpressionGoal(t *testing.T) {
+	store := setupStore(t)
+
+	dimensions := &models.Dimension{APPID: "spotify", OS: "iOS", Country: "us"}
+	total, daily := goalKeys("1", time.Now().UTC())
+	store.redisClient.Del(context.Background(), total, daily)
+	store.redisClient.Set(context.Background(), testCacheKey(t, store, dimensions),
+		`[{"cid": "1", "img": "image1.png", "cta": "Download", "impression_goal": {"total": 2}}]`, time.Minute)
+
+	result, err := store.Get(&gin.Context{}, dimensions)
+	require.NoError(t, err)
+	require.Len(t, *result, 1)
+
+	// Concurrent requests don't overshoot the goal.
+	var delivered atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivered.Add(int32(len(store.ReserveDeliveries(context.Background(), "", *result, 0))))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), delivered.Load())
+
+	pacing, err := store.Pacing(context.Background(), []models.Campaign{{CampaignID: "1",
+		ImpressionGoal: &models.ImpressionGoal{Total: 2}}}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Pacing{{CampaignID: "1", TotalGoal: 2, DeliveredTotal: 2, DeliveredToday: 2,
+		State: "goal_reached"}}, pacing)
+}
+
+func TestStore_SaveRulesBumpsRulesVersion(t *testing.T) {
+	store := setupStore(t)
+	ctx := context.Background()
+	dimensions := &models.Dimension{APPID: "com.whatsapp", OS: "ios", Country: "in"}
+
+	rules, err := store.GetRules(ctx, "whatsapp")
+	require.NoError(t, err)
+
+	before := testCacheKey(t, store, dimensions)
+	require.NoError(t, store.SaveRules(ctx, rules))
+
+	// The responses cached before the write are left behind, whichever campaigns they were matched with.
+	assert.NotEqual(t, before, testCacheKey(t, store, dimensions))
+}
+
+func TestMongoRepository_DeleteCampaign(t *testing.T) {
+	store := setupStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.Repository.DeleteCampaign(ctx, "whatsapp"))
+
+	_, err := store.Repository.GetRules(ctx, "whatsapp")
+	assert.Equal(t, rulesNotFound("whatsapp"), err)
+
+	assert.Equal(t, campaignNotFound("whatsapp"), store.Repository.DeleteCampaign(ctx, "whatsapp"))
+}
+
 func TestStore_InvalidateCache(t *testing.T) {
 	store := setupStore(t)
 
@@ -258,3 +370,27 @@ func TestStore_InvalidateCache(t *testing.T) {
 		})
 	}
 }
+
+func TestUnavailableError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{name: "MongoDB server selection", err: mongoError(topology.ServerSelectionError{Desc: description.Topology{}}),
+			statusCode: http.StatusServiceUnavailable},
+		{name: "MongoDB client disconnected", err: mongoError(mongo.ErrClientDisconnected), statusCode: http.StatusServiceUnavailable},
+		{name: "MongoDB command error", err: mongoError(mongo.CommandError{Code: 2}), statusCode: http.StatusInternalServerError},
+		{name: "PostgreSQL bad connection", err: postgresError(driver.ErrBadConn), statusCode: http.StatusServiceUnavailable},
+		{name: "PostgreSQL starting up", err: postgresError(&pq.Error{Code: "57P03"}), statusCode: http.StatusServiceUnavailable},
+		{name: "PostgreSQL unique violation", err: postgresError(&pq.Error{Code: "23505"}), statusCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var helperErr *helpers.Error
+			require.ErrorAs(t, tt.err, &helperErr)
+			assert.Equal(t, tt.statusCode, helperErr.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package stores
+
+import (
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/Durga-Chikkala/delivery-service/constants"
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// evaluateDimension mirrors the filter of createDimensionRule for a single campaign: the value matches when the
+// dimension has no rule with values, when it is in an include list or when an exclude list doesn't contain it. An
+// unspecified value only matches when the dimension has no rule with values.
+func evaluateDimension(rules []models.Rule, dimension, value string, patterns patternSet) models.DimensionVerdict {
+	verdict := models.DimensionVerdict{Dimension: dimension, Value: value, Verdict: constants.VerdictNoRule, Matched: true}
+
+	var restricted []models.Rule
+	for _, rule := range rules {
+		if rule.Dimension == dimension && (len(rule.Include) > 0 || len(rule.Exclude) > 0) {
+			restricted = append(restricted, rule)
+		}
+	}
+
+	if len(restricted) == 0 {
+		return verdict
+	}
+
+	if value == "" {
+		verdict.Matched = false
+		verdict.Verdict = constants.VerdictUnspecified
+		return verdict
+	}
+
+	for _, rule := range restricted {
+		if containsValue(dimension, rule.Include, value, patterns) {
+			verdict.Verdict = constants.VerdictIncluded
+			return verdict
+		}
+	}
+
+	for _, rule := range restricted {
+		if len(rule.Exclude) > 0 && !containsValue(dimension, rule.Exclude, value, patterns) {
+			verdict.Verdict = constants.VerdictNotExcluded
+			return verdict
+		}
+	}
+
+	verdict.Matched = false
+	verdict.Verdict = constants.VerdictNotIncluded
+
+	for _, rule := range restricted {
+		if containsValue(dimension, rule.Exclude, value, patterns) {
+			verdict.Verdict = constants.VerdictExcluded
+			break
+		}
+	}
+
+	return verdict
+}
+
+// containsValue tells whether one of the values of a rule matches the value of the request, either equal to it, a
+// pattern matching it or, for version dimensions, a range including it. Countries are compared canonical, rules saved
+// before they were still have names. The patterns are looked up in the set, and compiled when it doesn't have them.
+func containsValue(dimension string, values []string, value string, patterns patternSet) bool {
+	isVersion := slices.Contains(constants.VersionDimensions, dimension)
+
+	for _, ruleValue := range values {
+		if dimension == constants.Country {
+			ruleValue = helpers.CanonicalCountry(ruleValue)
+		}
+
+		switch {
+		case ruleValue == value:
+			return true
+		case helpers.IsPattern(ruleValue):
+			if patterns.match(ruleValue, value) {
+				return true
+			}
+		case isVersion && helpers.IsVersionRange(ruleValue):
+			if versionRange, err := helpers.ParseVersionRange(ruleValue); err == nil && versionRange.Matches(value) {
+				return true
+			}
+		case isVersion:
+			if canonical := helpers.CanonicalVersion(ruleValue); canonical != "" && canonical == helpers.CanonicalVersion(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// evaluateExpression matches when the campaign has no expression or when the expression holds for the request. An
+// invalid expression, which the validation doesn't let in, never holds.
+func evaluateExpression(expression string, dimensions *models.Dimension) models.DimensionVerdict {
+	verdict := models.DimensionVerdict{Dimension: constants.Expression, Value: expression,
+		Verdict: constants.VerdictNoRule, Matched: true}
+
+	if expression == "" {
+		return verdict
+	}
+
+	verdict.Verdict = constants.VerdictSatisfied
+
+	parsed, err := helpers.ParseExpression(expression)
+	if err != nil || !parsed.Evaluate(conditionHolds(dimensions, nil)) {
+		verdict.Verdict = constants.VerdictNotSatisfied
+		verdict.Matched = false
+	}
+
+	return verdict
+}
+
+// conditionHolds evaluates the conditions of expressions with the semantics of the rules: = and in hold when a value
+// matches the request like an include list, != and not in when none does. An unspecified value is never matched, nor
+// compares with a version.
+func conditionHolds(dimensions *models.Dimension, patterns patternSet) func(helpers.Condition) bool {
+	return func(condition helpers.Condition) bool {
+		value := dimensionValue(dimensions, condition.Dimension)
+		contains := value != "" && containsValue(condition.Dimension, condition.Values, value, patterns)
+
+		switch condition.Operator {
+		case helpers.OperatorEqual, helpers.OperatorIn:
+			return contains
+		case helpers.OperatorNotEqual, helpers.OperatorNotIn:
+			return !contains
+		default:
+			versionRange, err := helpers.ParseVersionRange(condition.Operator + condition.Values[0])
+			return err == nil && versionRange.Matches(value)
+		}
+	}
+}
+
+// matchRules evaluates every rule of a campaign and its expression against the request, the way the index matches
+// them.
+func matchRules(rules []models.Rule, expression string, dimensions *models.Dimension, local time.Time) bool {
+	for _, dimension := range constants.Dimensions {
+		if !evaluateDimension(rules, dimension, dimensionValue(dimensions, dimension), nil).Matched {
+			return false
+		}
+	}
+
+	return evaluateDaypart(rules, local).Matched && evaluateExpression(expression, dimensions).Matched
+}
+
+// evaluatedRules keeps the rules matched by the service rather than by the index or the store queries: the daypart
+// and version rules.
+func evaluatedRules(rules []models.Rule) []models.Rule {
+	var evaluated []models.Rule
+	for _, rule := range rules {
+		if rule.Dimension == constants.Daypart || slices.Contains(constants.VersionDimensions, rule.Dimension) {
+			evaluated = append(evaluated, rule)
+		}
+	}
+
+	return evaluated
+}
+
+// matchEvaluated tells whether the daypart and version rules match the request.
+func matchEvaluated(rules []models.Rule, dimensions *models.Dimension, local time.Time, patterns patternSet) bool {
+	if !evaluateDaypart(rules, local).Matched {
+		return false
+	}
+
+	for _, dimension := range constants.VersionDimensions {
+		if !evaluateDimension(rules, dimension, dimensionValue(dimensions, dimension), patterns).Matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// patternSet holds compiled patterns by the rule value they are written as, countries canonical.
+type patternSet map[string]*regexp.Regexp
+
+// add compiles the pattern values among the values of the dimension. An invalid pattern, which the validation doesn't
+// let in, is left out and never matches.
+func (p patternSet) add(dimension string, values []string) {
+	for _, value := range values {
+		if dimension == constants.Country {
+			value = helpers.CanonicalCountry(value)
+		}
+
+		if !helpers.IsPattern(value) {
+			continue
+		}
+
+		if compiled, err := helpers.CompilePattern(value); err == nil {
+			p[value] = compiled
+		}
+	}
+}
+
+// match tells whether the value matches the pattern, compiling the patterns the set doesn't have.
+func (p patternSet) match(pattern, value string) bool {
+	if compiled, ok := p[pattern]; ok {
+		return compiled.MatchString(value)
+	}
+
+	return helpers.MatchPattern(pattern, value)
+}
+
+// queriedDimensions are the dimensions the index and the store queries match, the others being evaluated.
+func queriedDimensions() []string {
+	var dimensions []string
+	for _, dimension := range constants.Dimensions {
+		if !slices.Contains(constants.VersionDimensions, dimension) {
+			dimensions = append(dimensions, dimension)
+		}
+	}
+
+	return dimensions
+}
+
+// queriedValues are the values the store queries look for in the rules for the value of the request, every way of
+// writing it for a country. There are none for an unspecified value.
+func queriedValues(dimension, value string) []string {
+	switch {
+	case value == "":
+		return []string{}
+	case dimension == constants.Country:
+		return helpers.CountryAliases(value)
+	default:
+		return []string{value}
+	}
+}
+
+func dimensionValue(dimensions *models.Dimension, dimension string) string {
+	switch dimension {
+	case constants.App:
+		return dimensions.APPID
+	case constants.Country:
+		return dimensions.Country
+	case constants.Os:
+		return dimensions.OS
+	case constants.AppVersion:
+		return dimensions.AppVersion
+	case constants.OsVersion:
+		return dimensions.OSVersion
+	case constants.Device:
+		return dimensions.Device
+	case constants.Lang:
+		return dimensions.Lang
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,254 @@
+package stores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+func TestEvaluateDimension(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []models.Rule
+		value    string
+		expected models.DimensionVerdict
+	}{
+		{
+			name:     "no rule",
+			rules:    []models.Rule{{Dimension: "os", Include: []string{"ios"}}},
+			value:    "us",
+			expected: models.DimensionVerdict{Dimension: "country", Value: "us", Verdict: "no_rule", Matched: true},
+		},
+		{
+			name:     "rule with empty lists",
+			rules:    []models.Rule{{Dimension: "country", Include: []string{}, Exclude: []string{}}},
+			value:    "us",
+			expected: models.DimensionVerdict{Dimension: "country", Value: "us", Verdict: "no_rule", Matched: true},
+		},
+		{
+			name:     "included",
+			rules:    []models.Rule{{Dimension: "country", Include: []string{"us", "canada"}}},
+			value:    "ca",
+			expected: models.DimensionVerdict{Dimension: "country", Value: "ca", Verdict: "included", Matched: true},
+		},
+		{
+			name:     "not included",
+			rules:    []models.Rule{{Dimension: "country", Include: []string{"us", "canada"}}},
+			value:    "in",
+			expected: models.DimensionVerdict{Dimension: "country", Value: "in", Verdict: "not_included", Matched: false},
+		},
+		{
+			name:     "excluded",
+			rules:    []models.Rule{{Dimension: "country", Exclude: []string{"india"}}},
+			value:    "in",
+			expected: models.DimensionVerdict{Dimension: "country", Value: "in", Verdict: "excluded", Matched: false},
+		},
+		{
+			name:     "not excluded",
+			rules:    []models.Rule{{Dimension: "country", Include: []string{"uk"}, Exclude: []string{"india"}}},
+			value:    "us",
+			expected: models.DimensionVerdict{Dimension: "country", Value: "us", Verdict: "not_excluded", Matched: true},
+		},
+		{
+			name:     "unspecified value with an exclude list",
+			rules:    []models.Rule{{Dimension: "country", Exclude: []string{"india"}}},
+			value:    "",
+			expected: models.DimensionVerdict{Dimension: "country", Verdict: "unspecified", Matched: false},
+		},
+		{
+			name:     "unspecified value without rule",
+			rules:    []models.Rule{{Dimension: "country", Exclude: []string{}}},
+			value:    "",
+			expected: models.DimensionVerdict{Dimension: "country", Verdict: "no_rule", Matched: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, evaluateDimension(tt.rules, "country", tt.value, nil))
+		})
+	}
+}
+
+func TestEvaluateDimension_Versions(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		value   string
+		matched bool
+	}{
+		{name: "at least", include: []string{">=12"}, value: "12.0.1", matched: true},
+		{name: "below the minimum", include: []string{">=12"}, value: "11.4", matched: false},
+		{name: "space separated bounds", include: []string{">= 12 <14"}, value: "14.0.0", matched: false},
+		{name: "tilde allows patches", include: []string{"~1.4"}, value: "1.4.9", matched: true},
+		{name: "tilde excludes the next minor", include: []string{"~1.4"}, value: "1.5.0", matched: false},
+		{name: "caret allows minors", include: []string{"^1.4"}, value: "1.9.0", matched: true},
+		{name: "caret on zero major", include: []string{"^0.4.2"}, value: "0.5.0", matched: false},
+		{name: "bare version is semver equal", include: []string{"5.2"}, value: "5.2.0", matched: true},
+		{name: "prerelease is below the release", include: []string{"<5.2.0"}, value: "5.2.0-beta.1", matched: true},
+		{name: "excluded build", exclude: []string{"=5.2.1"}, value: "5.2.1", matched: false},
+		{name: "not excluded build", exclude: []string{"=5.2.1"}, value: "5.2.2", matched: true},
+		{name: "value that isn't a version", include: []string{">=1"}, value: "latest", matched: false},
+		{name: "non semver value matches exactly", include: []string{"2024.1.1.7"}, value: "2024.1.1.7", matched: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := []models.Rule{{Dimension: "app_version", Include: tt.include, Exclude: tt.exclude}}
+
+			assert.Equal(t, tt.matched, evaluateDimension(rules, "app_version", tt.value, nil).Matched)
+		})
+	}
+}
+
+func TestEvaluateDimension_Countries(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		matched bool
+	}{
+		{name: "alpha-2 code", include: []string{"ca"}, matched: true},
+		{name: "name saved before countries were canonical", include: []string{"us", "canada"}, matched: true},
+		{name: "alpha-3 code", include: []string{"can"}, matched: true},
+		{name: "excluded name", exclude: []string{"canada"}, matched: false},
+		{name: "glob", include: []string{"c?"}, matched: true},
+		{name: "another country", include: []string{"cameroon"}, matched: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := []models.Rule{{Dimension: "country", Include: tt.include, Exclude: tt.exclude}}
+
+			assert.Equal(t, tt.matched, evaluateDimension(rules, "country", "ca", nil).Matched)
+		})
+	}
+}
+
+func TestQueriedValues(t *testing.T) {
+	assert.Equal(t, []string{}, queriedValues("country", ""))
+	assert.Equal(t, []string{"kp", "prk", "northkorea", "north korea", "dprk"}, queriedValues("country", "kp"))
+	assert.Equal(t, []string{"atlantis"}, queriedValues("country", "atlantis"))
+	assert.Equal(t, []string{"in"}, queriedValues("os", "in"))
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	dimensions := &models.Dimension{APPID: "com.spotify", Country: "in", OS: "android", AppVersion: "5.3.1"}
+
+	tests := []struct {
+		expression string
+		verdict    string
+	}{
+		{expression: "", verdict: "no_rule"},
+		{expression: "country = in", verdict: "satisfied"},
+		{expression: "country in (us, ca) or app = 'com.spotify*'", verdict: "satisfied"},
+		{expression: "country not in (us, ca) and os != ios", verdict: "satisfied"},
+		{expression: "country = india", verdict: "satisfied"},
+		{expression: "not (os = android or os = ios)", verdict: "not_satisfied"},
+		{expression: "app_version >= 5.2 and app_version < 6", verdict: "satisfied"},
+		{expression: "app_version in ('~5.3', 6.0.0)", verdict: "satisfied"},
+		{expression: "os_version < 12", verdict: "not_satisfied"},
+		{expression: "lang != en", verdict: "satisfied"},
+		{expression: "lang = en or", verdict: "not_satisfied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			verdict := evaluateExpression(tt.expression, dimensions)
+
+			assert.Equal(t, models.DimensionVerdict{Dimension: "expression", Value: tt.expression, Verdict: tt.verdict,
+				Matched: tt.verdict != "not_satisfied"}, verdict)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	netflix := &models.Campaign{CampaignID: "netflix", Status: "ACTIVE"}
+	rules := &models.TargetingRule{CampaignID: "netflix", Rules: []models.Rule{
+		{Dimension: "os", Include: []string{"ios"}},
+		{Dimension: "country", Include: []string{"uk", "germany"}, Exclude: []string{"india"}},
+	}}
+
+	explanation := explain(netflix, rules, &models.Dimension{APPID: "com.netflix", OS: "android", Country: "in"}, now)
+
+	assert.False(t, explanation.Delivered)
+	assert.Equal(t, "country is excluded, os is not_included", explanation.Reason)
+	assert.Equal(t, []models.DimensionVerdict{
+		{Dimension: "app", Value: "com.netflix", Verdict: "no_rule", Matched: true},
+		{Dimension: "country", Value: "in", Verdict: "excluded", Matched: false},
+		{Dimension: "os", Value: "android", Verdict: "not_included", Matched: false},
+		{Dimension: "app_version", Verdict: "no_rule", Matched: true},
+		{Dimension: "os_version", Verdict: "no_rule", Matched: true},
+		{Dimension: "device", Verdict: "no_rule", Matched: true},
+		{Dimension: "lang", Verdict: "no_rule", Matched: true},
+		{Dimension: "daypart", Value: "sun:12", Verdict: "no_rule", Matched: true},
+		{Dimension: "expression", Verdict: "no_rule", Matched: true},
+	}, explanation.Dimensions)
+
+	explanation = explain(netflix, rules, &models.Dimension{APPID: "com.netflix", OS: "ios", Country: "gb"}, now)
+
+	assert.True(t, explanation.Delivered)
+	assert.Equal(t, "delivered", explanation.Reason)
+
+	rules.Expression = "os = ios and device = tablet"
+	explanation = explain(netflix, rules, &models.Dimension{APPID: "com.netflix", OS: "ios", Country: "gb"}, now)
+	rules.Expression = ""
+
+	assert.False(t, explanation.Delivered)
+	assert.Equal(t, "expression is not_satisfied", explanation.Reason)
+
+	explanation = explain(&models.Campaign{CampaignID: "netflix", Status: "INACTIVE"}, rules,
+		&models.Dimension{APPID: "com.netflix", OS: "ios", Country: "gb"}, now)
+
+	assert.False(t, explanation.Delivered)
+	assert.Equal(t, "campaign is INACTIVE", explanation.Reason)
+
+	explanation = explain(netflix, nil, &models.Dimension{APPID: "com.netflix", OS: "ios", Country: "gb"}, now)
+
+	assert.False(t, explanation.Delivered)
+	assert.Equal(t, "campaign has no targeting rules", explanation.Reason)
+	assert.Empty(t, explanation.Dimensions)
+
+	startAt, endAt := now.Add(time.Hour), now.Add(-time.Hour)
+	ios := &models.Dimension{APPID: "com.netflix", OS: "ios", Country: "gb"}
+
+	explanation = explain(&models.Campaign{CampaignID: "netflix", Status: "ACTIVE", StartAt: &startAt,
+		Timezone: "Asia/Kolkata"}, rules, ios, now)
+
+	assert.False(t, explanation.Delivered)
+	assert.Equal(t, "campaign starts at 2026-03-01T18:30:00+05:30", explanation.Reason)
+
+	explanation = explain(&models.Campaign{CampaignID: "netflix", Status: "ACTIVE", EndAt: &endAt}, rules, ios, now)
+
+	assert.False(t, explanation.Delivered)
+	assert.Equal(t, "campaign ended at 2026-03-01T11:00:00Z", explanation.Reason)
+
+	explanation = explain(&models.Campaign{CampaignID: "netflix", Status: "ACTIVE", StartAt: &endAt, EndAt: &startAt},
+		rules, ios, now)
+
+	assert.True(t, explanation.Delivered)
+
+	evenings := &models.TargetingRule{CampaignID: "netflix", Rules: append(rules.Rules,
+		models.Rule{Dimension: "daypart", Windows: []models.TimeWindow{{Days: []string{"sun"}, Start: 18, End: 22}}})}
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explanation = explain(netflix, evenings, ios, now)
+
+	assert.False(t, explanation.Delivered)
+	assert.Equal(t, "daypart is outside_window", explanation.Reason)
+
+	explanation = explain(netflix, evenings, &models.Dimension{APPID: "com.netflix", OS: "ios", Country: "gb",
+		LocalTime: now.Add(time.Hour).In(kolkata)}, now)
+
+	assert.True(t, explanation.Delivered)
+	assert.Equal(t, models.DimensionVerdict{Dimension: "daypart", Value: "sun:18", Verdict: "in_window", Matched: true},
+		explanation.Dimensions[len(explanation.Dimensions)-2])
+}
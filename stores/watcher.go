@@ -0,0 +1,101 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	sourceChangeStream = "change_stream"
+	sourcePoll         = "poll"
+)
+
+var errChangesNotSupported = errors.New("change streams are not supported")
+
+// changeStreamer is implemented by the repositories able to push their changes. watchChanges calls observe for every
+// change and refresh once a batch of them is read, and returns errChangesNotSupported if the server can't stream.
+type changeStreamer interface {
+	watchChanges(ctx context.Context, observe func(operation string, at time.Time), refresh func()) error
+}
+
+// Watcher keeps the targeting index and the Redis cache in line with changes made directly in the repository. It
+// follows the repository's change stream where there is one, and polls it otherwise.
+type Watcher struct {
+	store        *Store
+	events       *prometheus.CounterVec
+	lag          prometheus.Histogram
+	pollInterval time.Duration
+}
+
+func NewWatcher(store *Store, events *prometheus.CounterVec, lag prometheus.Histogram, pollInterval time.Duration) *Watcher {
+	return &Watcher{store: store, events: events, lag: lag, pollInterval: pollInterval}
+}
+
+// Run blocks until the context is done.
+func (w *Watcher) Run(ctx context.Context) {
+	streamer, ok := w.store.Repository.(changeStreamer)
+	if !ok {
+		w.poll(ctx)
+		return
+	}
+
+	for ctx.Err() == nil {
+		err := streamer.watchChanges(ctx, w.observe, func() { w.refresh(ctx, sourceChangeStream) })
+		if errors.Is(err, errChangesNotSupported) {
+			w.store.logger.Info("Change streams are not supported, polling for changes", "Interval", w.pollInterval.String())
+			w.poll(ctx)
+
+			return
+		}
+
+		if err != nil && ctx.Err() == nil {
+			w.store.logger.Error("Error while Watching changes, retrying", "Error", err.Error())
+			w.refresh(ctx, sourcePoll)
+			sleep(ctx, w.pollInterval)
+		}
+	}
+}
+
+func (w *Watcher) observe(operation string, at time.Time) {
+	w.events.WithLabelValues(sourceChangeStream, operation).Inc()
+	w.lag.Observe(time.Since(at).Seconds())
+}
+
+func (w *Watcher) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.refresh(ctx, sourcePoll)
+		}
+	}
+}
+
+func (w *Watcher) refresh(ctx context.Context, source string) {
+	changed, err := w.store.RefreshIndex(ctx)
+	if err != nil {
+		w.store.logger.Error("Error while Refreshing targeting index", "Error", err.Error())
+		return
+	}
+
+	if source == sourcePoll {
+		w.events.WithLabelValues(sourcePoll, "change").Add(float64(len(changed)))
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,213 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Durga-Chikkala/delivery-service/helpers"
+	"github.com/Durga-Chikkala/delivery-service/models"
+)
+
+// streamingRepository streams its changes with watch, counting the calls.
+type streamingRepository struct {
+	Repository
+	watches atomic.Int32
+	watch   func(ctx context.Context, observe func(operation string, at time.Time), refresh func()) error
+}
+
+func (r *streamingRepository) watchChanges(ctx context.Context, observe func(operation string, at time.Time),
+	refresh func()) error {
+	r.watches.Add(1)
+
+	return r.watch(ctx, observe, refresh)
+}
+
+// recordingHistogram records what is observed.
+type recordingHistogram struct {
+	prometheus.Histogram
+	mu       sync.Mutex
+	observed []float64
+}
+
+func (h *recordingHistogram) Observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.observed = append(h.observed, value)
+}
+
+func (h *recordingHistogram) values() []float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]float64(nil), h.observed...)
+}
+
+// newTestWatcher returns a watcher polling every 10ms over a store with its index loaded.
+func newTestWatcher(t *testing.T, repo Repository) (*Watcher, *Store, *prometheus.CounterVec, *recordingHistogram) {
+	t.Helper()
+
+	cacheHit := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_hits"}, []string{"type"})
+	cacheMiss := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "cache_misses"}, []string{"type"})
+	store := New(repo, nil, helpers.InitializeLogger(), cacheHit, cacheMiss)
+
+	_, err := store.RefreshIndex(context.Background())
+	require.NoError(t, err)
+
+	events := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "change_events"}, []string{"source", "operation"})
+	lag := &recordingHistogram{}
+
+	return NewWatcher(&store, events, lag, 10*time.Millisecond), &store, events, lag
+}
+
+// runWatcher runs the watcher until the returned cancel is called, and fails unless Run then returns.
+func runWatcher(t *testing.T, watcher *Watcher) context.CancelFunc {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		watcher.Run(ctx)
+		close(done)
+	}()
+
+	return func() {
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run didn't return once the context was cancelled")
+		}
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	ctx := context.Background()
+	fileRepo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	watcher, store, events, lag := newTestWatcher(t, &countingRepository{Repository: fileRepo})
+	stop := runWatcher(t, watcher)
+	defer stop()
+
+	require.NoError(t, fileRepo.CreateCampaign(ctx, &models.Campaign{CampaignID: "added", Status: "ACTIVE"}))
+
+	assert.Eventually(t, func() bool { return testutil.ToFloat64(events.WithLabelValues(sourcePoll, "change")) == 1 },
+		time.Second, 10*time.Millisecond)
+
+	found, err := store.CampaignExists(ctx, "added")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Empty(t, lag.values())
+}
+
+func TestWatcher_FallsBackToPolling(t *testing.T) {
+	ctx := context.Background()
+	fileRepo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	repo := &streamingRepository{Repository: fileRepo,
+		watch: func(context.Context, func(string, time.Time), func()) error { return errChangesNotSupported }}
+
+	watcher, store, events, _ := newTestWatcher(t, repo)
+	stop := runWatcher(t, watcher)
+	defer stop()
+
+	require.NoError(t, fileRepo.DeleteCampaign(ctx, "spotify"))
+
+	assert.Eventually(t, func() bool { return testutil.ToFloat64(events.WithLabelValues(sourcePoll, "change")) == 1 },
+		time.Second, 10*time.Millisecond)
+
+	found, err := store.CampaignExists(ctx, "spotify")
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, int32(1), repo.watches.Load())
+}
+
+func TestWatcher_ChangeStream(t *testing.T) {
+	ctx := context.Background()
+	fileRepo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	streamed := make(chan struct{})
+	repo := &streamingRepository{Repository: fileRepo,
+		watch: func(ctx context.Context, observe func(string, time.Time), refresh func()) error {
+			if err := fileRepo.CreateCampaign(ctx, &models.Campaign{CampaignID: "added", Status: "ACTIVE"}); err != nil {
+				return err
+			}
+
+			observe("insert", time.Now().Add(-2*time.Second))
+			refresh()
+			close(streamed)
+
+			<-ctx.Done()
+
+			return ctx.Err()
+		}}
+
+	watcher, store, events, lag := newTestWatcher(t, repo)
+	stop := runWatcher(t, watcher)
+
+	select {
+	case <-streamed:
+	case <-time.After(time.Second):
+		t.Fatal("the change stream wasn't watched")
+	}
+
+	stop()
+
+	found, err := store.CampaignExists(ctx, "added")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(events.WithLabelValues(sourceChangeStream, "insert")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(events.WithLabelValues(sourcePoll, "change")))
+	require.Len(t, lag.values(), 1)
+	assert.GreaterOrEqual(t, lag.values()[0], float64(2))
+	assert.Equal(t, int32(1), repo.watches.Load())
+}
+
+func TestWatcher_StopsOnCancel(t *testing.T) {
+	fileRepo, err := NewFileRepository("./testdata")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		repo    Repository
+		watches int32
+	}{
+		{name: "polling", repo: &countingRepository{Repository: fileRepo}},
+		{name: "streaming", watches: 1, repo: &streamingRepository{Repository: fileRepo,
+			watch: func(ctx context.Context, _ func(string, time.Time), _ func()) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}}},
+		{name: "retrying a failing stream", watches: 2, repo: &streamingRepository{Repository: fileRepo,
+			watch: func(context.Context, func(string, time.Time), func()) error { return errors.New("connection reset") }}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher, _, _, _ := newTestWatcher(t, tt.repo)
+			stop := runWatcher(t, watcher)
+
+			time.Sleep(50 * time.Millisecond)
+			stop()
+
+			if repo, ok := tt.repo.(*streamingRepository); ok {
+				assert.GreaterOrEqual(t, repo.watches.Load(), tt.watches)
+			}
+		})
+	}
+}